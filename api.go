@@ -0,0 +1,20 @@
+package pexels
+
+import "context"
+
+// PexelsAPI lists the methods of Client that call the Pexels API endpoints.
+// Code that depends on PexelsAPI instead of *Client can substitute a mock or fake in its own tests.
+// It is kept next to Client, which is asserted to implement it, so the two cannot drift apart.
+type PexelsAPI interface {
+	GetPhotos(ctx context.Context, params *GetPhotosParams) (*GetPhotoResponse, error)
+	GetCurated(ctx context.Context, params *GetCuratedPhotoParams) (*GetPhotoResponse, error)
+	GetPhoto(ctx context.Context, id string) (*Photo, error)
+	GetVideos(ctx context.Context, params *GetVideosParams) (*GetVideosResponse, error)
+	GetPopularVideos(ctx context.Context, params *GetPopularVideosParams) (*GetVideosResponse, error)
+	GetVideo(ctx context.Context, id string) (*Video, error)
+	GetFeaturedCollections(ctx context.Context, params *GetFeaturedCollectionParams) (*GetCollectionsResponse, error)
+	GetUserCollections(ctx context.Context, params *GetFeaturedCollectionParams) (*GetCollectionsResponse, error)
+	GetCollection(ctx context.Context, params *GetCollectionMediaParams, id string) (*GetCollectionMedia, error)
+}
+
+var _ PexelsAPI = (*Client)(nil)
@@ -0,0 +1,103 @@
+package pexels
+
+import "math"
+
+// SquareTolerance is how far an aspect ratio may deviate from 1 for media to still be considered square.
+const SquareTolerance = 0.05
+
+// aspectRatio returns width divided by height, or 0 when height is zero.
+func aspectRatio(width, height int) float64 {
+	if height == 0 {
+		return 0
+	}
+	return float64(width) / float64(height)
+}
+
+// isSquare reports whether the ratio is within SquareTolerance of 1.
+func isSquare(ratio float64) bool {
+	return ratio != 0 && math.Abs(ratio-1) <= SquareTolerance
+}
+
+// AspectRatio returns the width of the photo divided by its height, or 0 if the height is zero.
+func (p Photo) AspectRatio() float64 {
+	return aspectRatio(p.Width, p.Height)
+}
+
+// IsLandscape reports whether the photo is wider than it is tall, beyond SquareTolerance.
+func (p Photo) IsLandscape() bool {
+	r := p.AspectRatio()
+	return r > 1 && !isSquare(r)
+}
+
+// IsPortrait reports whether the photo is taller than it is wide, beyond SquareTolerance.
+func (p Photo) IsPortrait() bool {
+	r := p.AspectRatio()
+	return r > 0 && r < 1 && !isSquare(r)
+}
+
+// IsSquare reports whether the photo's aspect ratio is within SquareTolerance of 1.
+func (p Photo) IsSquare() bool {
+	return isSquare(p.AspectRatio())
+}
+
+// BestCropFor returns the URL of the pre-cropped size best suited to a layout slot with the given width to height
+// ratio: Src.Portrait for slots taller than wide, Src.Landscape for wider ones, and Src.Original for square slots,
+// within SquareTolerance, or a non-positive ratio. If the chosen size has no URL, Src.Large is returned instead.
+func (p Photo) BestCropFor(targetRatio float64) string {
+	src := p.Src.Original
+	switch {
+	case targetRatio <= 0 || isSquare(targetRatio):
+	case targetRatio < 1:
+		src = p.Src.Portrait
+	default:
+		src = p.Src.Landscape
+	}
+	if src == "" {
+		return p.Src.Large
+	}
+	return src
+}
+
+// AspectRatio returns the width of the video divided by its height, or 0 if the height is zero.
+func (v Video) AspectRatio() float64 {
+	return aspectRatio(v.Width, v.Height)
+}
+
+// IsLandscape reports whether the video is wider than it is tall, beyond SquareTolerance.
+func (v Video) IsLandscape() bool {
+	r := v.AspectRatio()
+	return r > 1 && !isSquare(r)
+}
+
+// IsPortrait reports whether the video is taller than it is wide, beyond SquareTolerance.
+func (v Video) IsPortrait() bool {
+	r := v.AspectRatio()
+	return r > 0 && r < 1 && !isSquare(r)
+}
+
+// IsSquare reports whether the video's aspect ratio is within SquareTolerance of 1.
+func (v Video) IsSquare() bool {
+	return isSquare(v.AspectRatio())
+}
+
+// AspectRatio returns the width of the media divided by its height, or 0 if the height is zero.
+func (m CollectionMedia) AspectRatio() float64 {
+	return aspectRatio(m.Width, m.Height)
+}
+
+// IsLandscape reports whether the media is wider than it is tall, beyond SquareTolerance.
+func (m CollectionMedia) IsLandscape() bool {
+	r := m.AspectRatio()
+	return r > 1 && !isSquare(r)
+}
+
+// IsPortrait reports whether the media is taller than it is wide, beyond SquareTolerance.
+func (m CollectionMedia) IsPortrait() bool {
+	r := m.AspectRatio()
+	return r > 0 && r < 1 && !isSquare(r)
+}
+
+// IsSquare reports whether the media's aspect ratio is within SquareTolerance of 1.
+func (m CollectionMedia) IsSquare() bool {
+	return isSquare(m.AspectRatio())
+}
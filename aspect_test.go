@@ -0,0 +1,62 @@
+package pexels
+
+import "testing"
+
+func TestAspectRatio(t *testing.T) {
+	tests := []struct {
+		name                            string
+		width, height                   int
+		ratio                           float64
+		landscape, portrait, squareness bool
+	}{
+		{"landscape", 4000, 2000, 2, true, false, false},
+		{"portrait", 1080, 1920, 0.5625, false, true, false},
+		{"square", 1000, 1000, 1, false, false, true},
+		{"nearly square", 1040, 1000, 1.04, false, false, true},
+		{"zero height", 1000, 0, 0, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Photo{Width: tt.width, Height: tt.height}
+			v := Video{Width: tt.width, Height: tt.height}
+			m := CollectionMedia{Width: tt.width, Height: tt.height}
+			for kind, got := range map[string][4]interface{}{
+				"Photo":           {p.AspectRatio(), p.IsLandscape(), p.IsPortrait(), p.IsSquare()},
+				"Video":           {v.AspectRatio(), v.IsLandscape(), v.IsPortrait(), v.IsSquare()},
+				"CollectionMedia": {m.AspectRatio(), m.IsLandscape(), m.IsPortrait(), m.IsSquare()},
+			} {
+				want := [4]interface{}{tt.ratio, tt.landscape, tt.portrait, tt.squareness}
+				if got != want {
+					t.Errorf("%s = %v, want %v", kind, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBestCropFor(t *testing.T) {
+	photo := Photo{Src: PhotoSrc{Original: "original", Large: "large", Portrait: "portrait", Landscape: "landscape"}}
+	tests := []struct {
+		ratio float64
+		want  string
+	}{
+		{16.0 / 9, "landscape"},
+		{9.0 / 16, "portrait"},
+		{1, "original"},
+		{1.02, "original"},
+		{0, "original"},
+	}
+	for _, tt := range tests {
+		if got := photo.BestCropFor(tt.ratio); got != tt.want {
+			t.Errorf("BestCropFor(%g) = %q, want %q", tt.ratio, got, tt.want)
+		}
+	}
+
+	// Missing crops fall back to Large
+	bare := Photo{Src: PhotoSrc{Large: "large"}}
+	for _, ratio := range []float64{16.0 / 9, 9.0 / 16, 1} {
+		if got := bare.BestCropFor(ratio); got != "large" {
+			t.Errorf("BestCropFor(%g) without crops = %q, want large", ratio, got)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package pexels
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffFunc returns the delay to wait before the retry that follows the given failed attempt, counted from 1.
+// It is set with WithBackoff; a Retry-After header sent by the server still takes precedence.
+type BackoffFunc func(attempt int) time.Duration
+
+// maxBackoffDelay bounds uncapped exponential delays so that doubling them cannot overflow.
+const maxBackoffDelay = time.Duration(math.MaxInt64 / 2)
+
+// exponentialDelay returns base doubled for every attempt after the first, capped at max if max is positive.
+func exponentialDelay(base, max time.Duration, attempt int) time.Duration {
+	if base <= 0 || attempt < 1 {
+		return 0
+	}
+	limit := maxBackoffDelay
+	if max > 0 && max < limit {
+		limit = max
+	}
+	d := base
+	for i := 1; i < attempt && d < limit; i++ {
+		d *= 2
+		if d > limit || d <= 0 {
+			d = limit
+		}
+	}
+	return min(d, limit)
+}
+
+// FullJitter returns a BackoffFunc picking a random delay between zero and the exponential delay base * 2^(attempt-1),
+// capped at max if max is positive. Spreading retries over the whole window best avoids synchronized retries from
+// many clients hitting the API at once, at the cost of sometimes retrying almost immediately.
+func FullJitter(base, max time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		d := exponentialDelay(base, max, attempt)
+		if d <= 0 {
+			return 0
+		}
+		return time.Duration(rand.Int63n(int64(d) + 1))
+	}
+}
+
+// EqualJitter returns a BackoffFunc picking a random delay between half and all of the exponential delay
+// base * 2^(attempt-1), capped at max if max is positive. It guarantees a minimum wait while still spreading retries.
+// EqualJitter with the base delay of WithRetry and a one minute cap is the default strategy.
+func EqualJitter(base, max time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		d := exponentialDelay(base, max, attempt)
+		if d <= 0 {
+			return 0
+		}
+		return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+}
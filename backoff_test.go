@@ -0,0 +1,74 @@
+package pexels
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoffStrategies(t *testing.T) {
+	base, max := 100*time.Millisecond, time.Second
+	strategies := map[string]struct {
+		fn      BackoffFunc
+		minPart float64 // Lower bound of the delay as a fraction of the exponential delay
+	}{
+		"FullJitter":  {FullJitter(base, max), 0},
+		"EqualJitter": {EqualJitter(base, max), 0.5},
+	}
+	for name, s := range strategies {
+		for attempt := 1; attempt <= 8; attempt++ {
+			ceiling := min(base<<(attempt-1), max)
+			for i := 0; i < 50; i++ {
+				d := s.fn(attempt)
+				if d < time.Duration(float64(ceiling)*s.minPart) || d > ceiling {
+					t.Fatalf("%s(%d) = %v, want between %v and %v", name, attempt, d, time.Duration(float64(ceiling)*s.minPart), ceiling)
+				}
+			}
+		}
+		// Large attempt numbers stay at the cap instead of overflowing
+		if d := s.fn(200); d < 0 || d > max {
+			t.Errorf("%s(200) = %v, want at most %v", name, d, max)
+		}
+	}
+
+	// The exponential delay grows until the cap and then stays there
+	want := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, w := range want {
+		if got := exponentialDelay(base, max, i+1); got != w*time.Millisecond {
+			t.Errorf("exponentialDelay(%d) = %v, want %v", i+1, got, w*time.Millisecond)
+		}
+	}
+	if got := exponentialDelay(time.Second, 0, 100); got != maxBackoffDelay {
+		t.Errorf("uncapped exponentialDelay(100) = %v, want %v", got, maxBackoffDelay)
+	}
+}
+
+func TestDefaultBackoffCap(t *testing.T) {
+	client := NewClient("key", WithRetry(8, time.Second))
+	for attempt := 1; attempt <= 20; attempt++ {
+		if d := client.backoff(attempt); d > maxRetryDelay {
+			t.Errorf("backoff(%d) = %v, want at most %v", attempt, d, maxRetryDelay)
+		}
+	}
+	if d := client.backoff(20); d < maxRetryDelay/2 {
+		t.Errorf("backoff(20) = %v, want at least half of %v", d, maxRetryDelay)
+	}
+}
+
+func TestWithBackoff(t *testing.T) {
+	server, calls := newFlakyServer(2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	var attempts []int
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithRetry(3, time.Hour), WithBackoff(func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		return time.Millisecond
+	}))
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	if *calls != 3 || len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("got %d calls and backoff attempts %v, want 3 calls and [1 2]", *calls, attempts)
+	}
+}
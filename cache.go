@@ -0,0 +1,223 @@
+package pexels
+
+import (
+	"container/list"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores raw API response bodies keyed by request URL.
+// Implementations must be safe for concurrent use; a Redis or memcached backed Cache can be supplied with WithCacheBackend.
+type Cache interface {
+	Get(key string) ([]byte, bool)                   // Get returns the body stored under key, if present and not expired
+	Set(key string, value []byte, ttl time.Duration) // Set stores a body under key for the given lifetime
+	Clear()                                          // Clear removes every entry from the cache
+}
+
+// maxCacheEntries is the number of responses the in-memory cache of NewMemoryCache holds. Beyond it, expired entries
+// are swept and then the least recently used one is evicted, so that long paginated crawls stay bounded.
+const maxCacheEntries = 1024
+
+// memoryCache is the in-memory Cache used by WithCache, evicting the least recently used entry beyond its limit.
+type memoryCache struct {
+	mu      sync.Mutex
+	limit   int                      // Maximum number of entries
+	entries map[string]*list.Element // Elements of order by key
+	order   *list.List               // *memoryCacheEntry values, most recently used first
+}
+
+// memoryCacheEntry is a cached body along with its key and expiry time.
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns an in-memory Cache safe for concurrent use. It holds the 1024 most recently used entries.
+func NewMemoryCache() Cache {
+	return newMemoryCache(maxCacheEntries)
+}
+
+// newMemoryCache returns an empty memoryCache holding at most limit entries.
+func newMemoryCache(limit int) *memoryCache {
+	return &memoryCache{limit: limit, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// Get returns the body stored under key, if present and not expired.
+func (m *memoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expires) {
+		m.remove(e)
+		return nil, false
+	}
+	m.order.MoveToFront(e)
+	return entry.value, true
+}
+
+// Set stores a body under key for the given lifetime. When the cache is full, expired entries are swept first and
+// the least recently used entry is evicted if that did not make room.
+func (m *memoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expires := time.Now().Add(ttl)
+	if e, ok := m.entries[key]; ok {
+		entry := e.Value.(*memoryCacheEntry)
+		entry.value, entry.expires = value, expires
+		m.order.MoveToFront(e)
+		return
+	}
+	if m.order.Len() >= m.limit {
+		m.sweep()
+	}
+	for m.order.Len() >= m.limit {
+		m.remove(m.order.Back())
+	}
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key: key, value: value, expires: expires})
+}
+
+// sweep removes every expired entry. The caller must hold m.mu.
+func (m *memoryCache) sweep() {
+	now := time.Now()
+	for e := m.order.Front(); e != nil; {
+		next := e.Next()
+		if now.After(e.Value.(*memoryCacheEntry).expires) {
+			m.remove(e)
+		}
+		e = next
+	}
+}
+
+// remove deletes an entry. The caller must hold m.mu.
+func (m *memoryCache) remove(e *list.Element) {
+	m.order.Remove(e)
+	delete(m.entries, e.Value.(*memoryCacheEntry).key)
+}
+
+// Clear removes every entry from the cache.
+func (m *memoryCache) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = make(map[string]*list.Element)
+	m.order.Init()
+}
+
+// ClearCache removes every cached response and stored conditional request validator.
+// It does nothing when neither WithCache nor WithConditionalRequests is configured.
+func (c *Client) ClearCache() {
+	if c.cache != nil {
+		c.cache.Clear()
+	}
+	if c.validators != nil {
+		c.validators.clear()
+	}
+}
+
+// cacheTTL returns how long a response with the given headers may be cached.
+// A Cache-Control max-age takes precedence over the default ttl, and no-store or no-cache disable caching.
+func cacheTTL(header http.Header, ttl time.Duration) time.Duration {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-store" || directive == "no-cache":
+			return 0
+		case strings.HasPrefix(directive, "max-age="):
+			if secs, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil {
+				return time.Duration(secs) * time.Second
+			}
+		}
+	}
+	return ttl
+}
+
+// validator holds the ETag and Last-Modified validators of a previous response along with its body.
+type validator struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// maxValidators is the number of responses a validatorStore remembers. Each one holds a full response body, so
+// once the limit is reached the least recently used entry is evicted to keep long paginated crawls bounded.
+const maxValidators = 256
+
+// validatorStore keeps the validators of previous GET responses keyed by request URL, evicting the least recently
+// used entry beyond its limit. It is safe for concurrent use.
+type validatorStore struct {
+	mu      sync.Mutex
+	limit   int                      // Maximum number of entries
+	entries map[string]*list.Element // Elements of order by request URL
+	order   *list.List               // validatorEntry values, most recently used first
+}
+
+// validatorEntry is a validator stored along with its key, so that an evicted list element can be removed from entries.
+type validatorEntry struct {
+	key string
+	v   validator
+}
+
+// newValidatorStore returns an empty validatorStore holding at most limit entries.
+func newValidatorStore(limit int) *validatorStore {
+	return &validatorStore{limit: limit, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// apply sets the conditional request headers for key on req and returns the stored validator, or nil if there is none.
+func (s *validatorStore) apply(key string, req *http.Request) *validator {
+	s.mu.Lock()
+	e, ok := s.entries[key]
+	var v validator
+	if ok {
+		s.order.MoveToFront(e)
+		v = e.Value.(*validatorEntry).v
+	}
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	if v.etag != "" {
+		req.Header.Set("If-None-Match", v.etag)
+	}
+	if v.lastModified != "" {
+		req.Header.Set("If-Modified-Since", v.lastModified)
+	}
+	return &v
+}
+
+// store records the validators of a response, if it carries any, evicting the least recently used entry if the
+// store is full.
+func (s *validatorStore) store(key string, header http.Header, body []byte) {
+	etag, lastModified := header.Get("ETag"), header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := validator{etag: etag, lastModified: lastModified, body: body}
+	if e, ok := s.entries[key]; ok {
+		e.Value.(*validatorEntry).v = v
+		s.order.MoveToFront(e)
+		return
+	}
+	s.entries[key] = s.order.PushFront(&validatorEntry{key: key, v: v})
+	for s.order.Len() > s.limit {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*validatorEntry).key)
+	}
+}
+
+// clear removes every stored validator.
+func (s *validatorStore) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]*list.Element)
+	s.order.Init()
+}
@@ -0,0 +1,228 @@
+package pexels
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCountingServer returns a server serving an empty photo list with the given Cache-Control header, counting requests.
+func newCountingServer(cacheControl string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		w.Write([]byte(`{"page":1,"per_page":5,"total_results":1,"photos":[{"id":1}]}`))
+	}))
+	return server, &calls
+}
+
+func TestCache(t *testing.T) {
+	server, calls := newCountingServer("")
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithCache(time.Minute))
+	ctx := context.Background()
+
+	// Identical requests are served from the cache
+	for i := 0; i < 3; i++ {
+		resp, err := client.GetCurated(ctx, &GetCuratedPhotoParams{})
+		if err != nil {
+			t.Fatalf("GetCurated failed: %v", err)
+		}
+		if len(resp.Photos) != 1 {
+			t.Errorf("GetCurated failed: expected 1 photo, got %d", len(resp.Photos))
+		}
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 request, got %d", *calls)
+	}
+
+	// A different URL misses the cache
+	if _, err := client.GetCurated(ctx, &GetCuratedPhotoParams{Page: Int(2)}); err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("expected 2 requests, got %d", *calls)
+	}
+
+	// Clearing the cache forces a new request
+	client.ClearCache()
+	if _, err := client.GetCurated(ctx, &GetCuratedPhotoParams{}); err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("expected 3 requests, got %d", *calls)
+	}
+}
+
+func TestCacheControl(t *testing.T) {
+	tests := []struct {
+		cacheControl string
+		wantCalls    int32
+	}{
+		{"no-store", 2},
+		{"max-age=0", 2},
+		{"public, max-age=60", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cacheControl, func(t *testing.T) {
+			server, calls := newCountingServer(tt.cacheControl)
+			defer server.Close()
+
+			client := NewClient("key", WithBaseURL(server.URL+"/"), WithCache(time.Minute))
+			for i := 0; i < 2; i++ {
+				if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+					t.Fatalf("GetCurated failed: %v", err)
+				}
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("expected %d requests, got %d", tt.wantCalls, *calls)
+			}
+		})
+	}
+}
+
+func TestMemoryCacheLimit(t *testing.T) {
+	cache := newMemoryCache(3)
+	for _, key := range []string{"a", "b", "c"} {
+		cache.Set(key, []byte(key), time.Minute)
+	}
+
+	// Reading a makes b the least recently used entry, evicted when d is stored
+	cache.Get("a")
+	cache.Set("d", []byte("d"), time.Minute)
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true, "d": true} {
+		if _, ok := cache.Get(key); ok != want {
+			t.Errorf("Get(%s) found = %v, want %v", key, ok, want)
+		}
+	}
+
+	// Expired entries are swept before a live one is evicted
+	cache.Set("c", []byte("c"), -time.Second)
+	cache.Set("e", []byte("e"), time.Minute)
+	for key, want := range map[string]bool{"a": true, "c": false, "d": true, "e": true} {
+		if _, ok := cache.Get(key); ok != want {
+			t.Errorf("Get(%s) found = %v, want %v", key, ok, want)
+		}
+	}
+
+	// Many distinct keys never grow the cache beyond its limit
+	for i := 0; i < 100; i++ {
+		cache.Set(strconv.Itoa(i), nil, time.Minute)
+	}
+	if len(cache.entries) != 3 || cache.order.Len() != 3 {
+		t.Errorf("cache holds %d entries and %d list elements, want 3", len(cache.entries), cache.order.Len())
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	cache := NewMemoryCache()
+	cache.Set("expired", []byte("x"), -time.Second)
+	if _, ok := cache.Get("expired"); ok {
+		t.Errorf("Get returned an expired entry")
+	}
+	cache.Set("fresh", []byte("x"), time.Minute)
+	if v, ok := cache.Get("fresh"); !ok || string(v) != "x" {
+		t.Errorf("Get(fresh) = %q, %v", v, ok)
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	var full, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		w.Write([]byte(`{"page":1,"photos":[{"id":7}]}`))
+	}))
+	defer server.Close()
+
+	var statuses []int
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithConditionalRequests(), WithLogger(func(info RequestInfo) {
+		statuses = append(statuses, info.StatusCode)
+	}))
+	for i := 0; i < 3; i++ {
+		resp, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{})
+		if err != nil {
+			t.Fatalf("GetCurated failed: %v", err)
+		}
+		if len(resp.Photos) != 1 || resp.Photos[0].ID != 7 {
+			t.Errorf("GetCurated returned %+v, want the stored photo", resp)
+		}
+	}
+	if full != 1 || notModified != 2 {
+		t.Errorf("got %d full and %d not modified responses, want 1 and 2", full, notModified)
+	}
+	if len(statuses) != 3 || statuses[1] != http.StatusNotModified {
+		t.Errorf("logged statuses %v, want 200 then 304s", statuses)
+	}
+
+	// Clearing the cache drops the validators
+	client.ClearCache()
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	if full != 2 {
+		t.Errorf("got %d full responses after ClearCache, want 2", full)
+	}
+}
+
+func TestValidatorStoreEviction(t *testing.T) {
+	store := newValidatorStore(2)
+	header := http.Header{"Etag": {`"v1"`}}
+	store.store("a", header, []byte("a"))
+	store.store("b", header, []byte("b"))
+
+	// Using a makes b the least recently used entry, evicted when c is stored
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	if store.apply("a", req) == nil {
+		t.Fatalf("validator for a missing before the limit was reached")
+	}
+	store.store("c", header, []byte("c"))
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+		if got := store.apply(key, req) != nil; got != want {
+			t.Errorf("validator for %s stored = %v, want %v", key, got, want)
+		}
+	}
+	if len(store.entries) != 2 || store.order.Len() != 2 {
+		t.Errorf("store holds %d entries and %d list elements, want 2", len(store.entries), store.order.Len())
+	}
+
+	// Responses without validators are not stored
+	store.store("d", http.Header{}, []byte("d"))
+	if _, ok := store.entries["d"]; ok {
+		t.Errorf("stored a response without validators")
+	}
+}
+
+func TestResponseMetaFromCache(t *testing.T) {
+	server, _ := newCountingServer("")
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithCache(time.Minute))
+	for i, want := range []ResponseMeta{{StatusCode: http.StatusOK}, {FromCache: true}, {FromCache: true}} {
+		var meta ResponseMeta
+		ctx := ContextWithResponseMeta(context.Background(), &meta)
+		if _, err := client.GetCurated(ctx, &GetCuratedPhotoParams{}); err != nil {
+			t.Fatalf("GetCurated failed: %v", err)
+		}
+		if meta.FromCache != want.FromCache || meta.StatusCode != want.StatusCode || (meta.Response == nil) != want.FromCache {
+			t.Errorf("call %d meta = %+v, want %+v", i, meta, want)
+		}
+	}
+	if stats := client.Stats(); stats.CacheHits != 2 || stats.TotalRequests != 1 {
+		t.Errorf("Stats() = %+v, want 2 cache hits and 1 request", stats)
+	}
+}
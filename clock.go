@@ -0,0 +1,34 @@
+package pexels
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the time source used by the retry and auto-throttle logic, set with WithClock.
+// Now returns the current time, and Sleep pauses for d or returns the context error once ctx is done.
+// Substituting a fake makes backoff and throttling testable without real waits.
+type Clock interface {
+	Now() time.Time
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// realClock is the Clock backed by the time package, used unless WithClock is set.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	return sleep(ctx, d)
+}
+
+// timeSource returns the Clock set with WithClock, or realClock for a Client built as a struct literal rather than
+// with NewClient.
+func (c *Client) timeSource() Clock {
+	if c.clock == nil {
+		return realClock{}
+	}
+	return c.clock
+}
@@ -0,0 +1,125 @@
+package pexels
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when Sleep is called, recording every requested sleep.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d > 0 {
+		f.sleeps = append(f.sleeps, d)
+		f.now = f.now.Add(d)
+	}
+	return ctx.Err()
+}
+
+func TestWithClockRetry(t *testing.T) {
+	server, calls := newFlakyServer(2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithClock(clock), WithRetry(3, time.Hour),
+		WithBackoff(func(attempt int) time.Duration { return time.Duration(attempt) * time.Minute }))
+	start := time.Now()
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("GetCurated slept in real time")
+	}
+	if *calls != 3 || len(clock.sleeps) != 2 || clock.sleeps[0] != time.Minute || clock.sleeps[1] != 2*time.Minute {
+		t.Errorf("got %d calls and sleeps %v, want 3 calls and [1m0s 2m0s]", *calls, clock.sleeps)
+	}
+}
+
+func TestWithClockRetryAfterDate(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// The date is long past in wall-clock time but 30s ahead of the fake clock
+			w.Header().Set("Retry-After", clock.Now().Add(30*time.Second).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithClock(clock), WithRetry(2, time.Hour))
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	if calls != 2 || len(clock.sleeps) != 1 || clock.sleeps[0] != 30*time.Second {
+		t.Errorf("got %d calls and sleeps %v, want 2 calls and [30s]", calls, clock.sleeps)
+	}
+}
+
+func TestWithClockThrottle(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	reset := clock.now.Add(40 * time.Second).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Limit", "100")
+		w.Header().Set("X-Ratelimit-Remaining", "3")
+		w.Header().Set("X-Ratelimit-Reset", strconv.FormatInt(reset, 10))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithClock(clock), WithAutoThrottle())
+	if d := client.ThrottleDelay(); d != 0 {
+		t.Errorf("ThrottleDelay() before any response = %v, want 0", d)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetPhoto(context.Background(), "1"); err != nil {
+			t.Fatalf("GetPhoto failed: %v", err)
+		}
+	}
+	// 40s until the reset split over the 3 remaining requests plus one
+	if len(clock.sleeps) != 1 || clock.sleeps[0] != 10*time.Second {
+		t.Errorf("throttle sleeps = %v, want [10s]", clock.sleeps)
+	}
+	// The fake time moved 10s, so 30s remain for the same quota
+	if d := client.ThrottleDelay(); d != 7500*time.Millisecond {
+		t.Errorf("ThrottleDelay() = %v, want 7.5s", d)
+	}
+}
+
+func TestStructLiteralClientClock(t *testing.T) {
+	server, calls := newFlakyServer(1, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	// Throttling and retries fall back to the real clock when the client was not created with NewClient
+	client := (&Client{BaseURL: server.URL + "/", ApiKey: "key", HTTPClient: server.Client(), Version: DefaultVersion}).
+		Clone(WithAutoThrottle(), WithRetry(2, time.Millisecond))
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("got %d calls, want 2", *calls)
+	}
+	if d := client.ThrottleDelay(); d != 0 {
+		t.Errorf("ThrottleDelay() = %v, want 0 without rate limit headers", d)
+	}
+}
@@ -2,8 +2,11 @@ package pexels
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"image/color"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // Collection represents a collection in the Pexels API.
@@ -27,23 +30,38 @@ type GetCollectionsResponse struct {
 	PrevPage     string       `json:"prev_page"`     // URL to the previous page of results
 }
 
+// PublicOnly returns the collections of the page that are not private, e.g. to build share links from the result of
+// GetUserCollections. The API has no parameter to filter on visibility, so this is done client-side and TotalResults
+// still counts private collections. The response is not modified.
+func (r GetCollectionsResponse) PublicOnly() []Collection {
+	public := make([]Collection, 0, len(r.Collections))
+	for _, c := range r.Collections {
+		if !c.Private {
+			public = append(public, c)
+		}
+	}
+	return public
+}
+
 // GetFeaturedCollectionParams represents the parameters for the GetFeaturedCollection function.
 type GetFeaturedCollectionParams struct {
-	Page    int `url:"page"`     // Page number for paginated results
-	PerPage int `url:"per_page"` // Number of results per page
+	Page    *int       `url:"page,omitempty"`     // Page number for paginated results, 1 when nil
+	PerPage *int       `url:"per_page,omitempty"` // Number of results per page, the endpoint default when nil, clamped to MaxPerPage
+	Extra   url.Values // Additional query parameters; explicit fields take precedence on key collisions
 }
 
 // GetCollectionMediaParams represents the parameters for the GetCollectionMedia function.
 type GetCollectionMediaParams struct {
-	Type    string `url:"type"`     // Type of media to retrieve (e.g., photos, videos)
-	Sort    string `url:"sort"`     // Sorting order of the media (e.g., popular, latest)
-	Page    int    `url:"page"`     // Page number for paginated results
-	PerPage int    `url:"per_page"` // Number of results per page
+	Type    string     `url:"type,omitempty"`     // Type of media to retrieve (e.g., photos, videos)
+	Sort    SortOrder  `url:"sort,omitempty"`     // Sorting order of the media, SortPopular or SortLatest
+	Page    *int       `url:"page,omitempty"`     // Page number for paginated results, 1 when nil
+	PerPage *int       `url:"per_page,omitempty"` // Number of results per page, the endpoint default when nil, clamped to MaxPerPage
+	Extra   url.Values // Additional query parameters; explicit fields take precedence on key collisions
 }
 
 // CollectionMedia represents the media in a collection in the Pexels API.
 type CollectionMedia struct {
-	Type            string         `json:"type"`             // Type of the media
+	Type            MediaType      `json:"type"`             // Type of the media, see IsPhoto and IsVideo
 	ID              int            `json:"id"`               // Unique identifier for the media
 	Width           int            `json:"width"`            // Width of the media in pixels
 	Height          int            `json:"height"`           // Height of the media in pixels
@@ -55,14 +73,86 @@ type CollectionMedia struct {
 	Src             PhotoSrc       `json:"src"`              // Object containing URLs to different sizes of the media
 	Liked           bool           `json:"liked"`            // Indicates if the media is liked
 	Duration        int            `json:"duration"`         // Duration of the video in seconds
-	FullRes         any            `json:"full_res"`         // Full resolution of the video
-	Tags            []any          `json:"tags"`             // Tags of the media
+	FullRes         *string        `json:"full_res"`         // URL to the full resolution of the video, nil if unavailable
+	Tags            []string       `json:"tags"`             // Tags of the media
 	Image           string         `json:"image"`            // URL to the video's image
 	User            User           `json:"user"`             // User who uploaded the media
-	VideoFiles      VideoFile      `json:"video_files"`      // Files of the video
+	VideoFiles      []VideoFile    `json:"video_files"`      // Files of the video
 	VideoPictures   []VideoPicture `json:"video_pictures"`   // Pictures of the video
 }
 
+// UnmarshalJSON decodes a media item, tolerating a null or absent full_res and tags as well as non-string values in them.
+func (m *CollectionMedia) UnmarshalJSON(data []byte) error {
+	type alias CollectionMedia
+	aux := struct {
+		*alias
+		FullRes json.RawMessage `json:"full_res"`
+		Tags    json.RawMessage `json:"tags"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	m.FullRes = decodeFullRes(aux.FullRes)
+	m.Tags = decodeTags(aux.Tags)
+	return nil
+}
+
+// ColorRGBA parses the average color of the media.
+// It returns the color with full alpha, or an error if AvgColor is not in the #rgb or #rrggbb form.
+func (m CollectionMedia) ColorRGBA() (color.RGBA, error) {
+	return parseHexColor(m.AvgColor)
+}
+
+// IsPhoto reports whether the media item is a photo. The type is compared case-insensitively.
+func (m CollectionMedia) IsPhoto() bool {
+	return strings.EqualFold(string(m.Type), string(MediaTypePhoto))
+}
+
+// IsVideo reports whether the media item is a video. The type is compared case-insensitively.
+func (m CollectionMedia) IsVideo() bool {
+	return strings.EqualFold(string(m.Type), string(MediaTypeVideo))
+}
+
+// DurationString returns the duration of a video media item for display, as m:ss or h:mm:ss, e.g. "1:05".
+func (m CollectionMedia) DurationString() string {
+	return formatDuration(m.Duration)
+}
+
+// Photo converts the media item to a Photo. ID, Width, Height, URL, Photographer, PhotographerURL, PhotographerID,
+// AvgColor, Src and Liked are copied; Alt is not part of collection media and is left empty.
+func (m CollectionMedia) Photo() Photo {
+	return Photo{
+		ID:              m.ID,
+		Width:           m.Width,
+		Height:          m.Height,
+		URL:             m.URL,
+		Photographer:    m.Photographer,
+		PhotographerURL: m.PhotographerURL,
+		PhotographerID:  m.PhotographerID,
+		AvgColor:        m.AvgColor,
+		Src:             m.Src,
+		Liked:           m.Liked,
+	}
+}
+
+// Video converts the media item to a Video. ID, Width, Height, URL, Image, FullRes, Tags, Duration, User,
+// VideoFiles and VideoPictures are copied.
+func (m CollectionMedia) Video() Video {
+	return Video{
+		ID:            m.ID,
+		Width:         m.Width,
+		Height:        m.Height,
+		URL:           m.URL,
+		Image:         m.Image,
+		FullRes:       m.FullRes,
+		Tags:          m.Tags,
+		Duration:      m.Duration,
+		User:          m.User,
+		VideoFiles:    m.VideoFiles,
+		VideoPictures: m.VideoPictures,
+	}
+}
+
 // GetCollectionMedia represents the response from the GetCollectionMedia function.
 type GetCollectionMedia struct {
 	ID           string            `json:"id"`            // Unique identifier for the collection
@@ -75,24 +165,17 @@ type GetCollectionMedia struct {
 }
 
 func (c *Client) getCollections(ctx context.Context, params *GetFeaturedCollectionParams, own bool) (*GetCollectionsResponse, error) {
-	if params.Page == 0 {
-		params.Page = 1
-	}
-	if params.PerPage == 0 {
-		params.PerPage = 5
+	if err := c.applyPaging(&params.Page, &params.PerPage, 5); err != nil {
+		return nil, err
 	}
-	url := fmt.Sprintf("%s%s/collections/featured?%s", c.BaseURL, c.Version, c.structToURLValues(*params).Encode())
+	url := c.apiURL("collections/featured", c.structToURLValues(*params))
 	if own {
-		url = fmt.Sprintf("%s%s/collections?%s", c.BaseURL, c.Version, c.structToURLValues(*params).Encode())
+		url = c.apiURL("collections", c.structToURLValues(*params))
 	}
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, url)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.ApiKey)
 
 	var resp GetCollectionsResponse = GetCollectionsResponse{}
 	err = c.sendRequest(ctx, req, &resp)
@@ -104,28 +187,24 @@ func (c *Client) getCollections(ctx context.Context, params *GetFeaturedCollecti
 }
 
 // GetCollection retrieves a collection from the Pexels API.
-// It takes a context, GetCollectionMediaParams, and an ID as input and returns a CollectionMedia and an error.
-// The GetCollectionMediaParams specify the type, sort, page, and per page parameters.
+// It takes a context, GetCollectionMediaParams, and an ID as input and returns a GetCollectionMedia and an error.
+// The GetCollectionMediaParams specify the type, sort, page, and per page parameters; an unknown Sort returns an error without a request.
 // The ID is the unique identifier for the collection.
-// The CollectionMedia contains the type, ID, width, height, URL, photographer, photographer URL, photographer ID, average color, source, liked status, duration, full resolution, tags, image URL, user, video files, and video pictures of the media in the collection.
-func (c *Client) GetCollection(ctx context.Context, params *GetCollectionMediaParams, id string) (*CollectionMedia, error) {
-	if params.Page == 0 {
-		params.Page = 1
+// The GetCollectionMedia contains the collection ID, the current page number, the number of results per page, the total number of results, URLs to the next and previous pages of results, and a list of media in the collection.
+func (c *Client) GetCollection(ctx context.Context, params *GetCollectionMediaParams, id string) (*GetCollectionMedia, error) {
+	if err := c.applyPaging(&params.Page, &params.PerPage, 5); err != nil {
+		return nil, err
 	}
-	if params.PerPage == 0 {
-		params.PerPage = 5
+	if err := params.validate(); err != nil {
+		return nil, err
 	}
-	url := fmt.Sprintf("%s%s/collections/%s?%s", c.BaseURL, c.Version, id, c.structToURLValues(*params).Encode())
-	req, err := http.NewRequest("GET", url, nil)
+	url := c.apiURL("collections/"+id, c.structToURLValues(*params))
+	req, err := c.newRequest(ctx, http.MethodGet, url)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.ApiKey)
 
-	var resp CollectionMedia = CollectionMedia{}
+	var resp GetCollectionMedia = GetCollectionMedia{}
 	err = c.sendRequest(ctx, req, &resp)
 	if err != nil {
 		return nil, err
@@ -148,3 +227,72 @@ func (c *Client) GetFeaturedCollections(ctx context.Context, params *GetFeatured
 func (c *Client) GetUserCollections(ctx context.Context, params *GetFeaturedCollectionParams) (*GetCollectionsResponse, error) {
 	return c.getCollections(ctx, params, true)
 }
+
+// AllCollectionMedia retrieves all media in a collection from the Pexels API by following the next page URLs.
+// It takes a context, an ID, GetCollectionMediaParams, and a maximum number of items as input and returns a list of CollectionMedia and an error.
+// The GetCollectionMediaParams specify the type, sort, and per page parameters of the first page; subsequent pages follow NextPage.
+// A maxItems of zero or less fetches every page, otherwise the walk stops once maxItems media have been collected.
+// If a page fails, the media collected so far are returned together with the error.
+func (c *Client) AllCollectionMedia(ctx context.Context, id string, params *GetCollectionMediaParams, maxItems int) ([]CollectionMedia, error) {
+	return CollectCollectionMedia(c.CollectionMediaIter(ctx, id, params), maxItems)
+}
+
+// GetCollectionInfo retrieves lightweight information about a collection from the Pexels API.
+// It takes a context and an ID as input and returns a Collection and an error.
+// The Pexels API has no endpoint returning a single collection's metadata, so the information is derived from
+// the first page of the collection's media, requested with a single result per page: it costs exactly one request
+// and only ID and MediaCount are filled in. Title, description and per-type counts are available from
+// GetUserCollections or GetFeaturedCollections.
+func (c *Client) GetCollectionInfo(ctx context.Context, id string) (*Collection, error) {
+	resp, err := c.GetCollection(ctx, &GetCollectionMediaParams{Page: Int(1), PerPage: Int(1)}, id)
+	if err != nil {
+		return nil, err
+	}
+	return &Collection{ID: resp.ID, MediaCount: resp.TotalResults}, nil
+}
+
+// GetCollectionPhotos retrieves the photos of a collection from the Pexels API.
+// It takes a context, an ID, and GetCollectionMediaParams as input and returns a list of photos and an error.
+// Type is set to "photos" on a copy of params, and each media item is converted with CollectionMedia.Photo.
+// Only the requested page is returned; use NextCollectionMediaPage or AllCollectionMedia to walk further.
+func (c *Client) GetCollectionPhotos(ctx context.Context, id string, params *GetCollectionMediaParams) ([]Photo, error) {
+	resp, err := c.getCollectionOfType(ctx, id, params, "photos")
+	if err != nil {
+		return nil, err
+	}
+	var photos []Photo
+	for _, m := range resp.Media {
+		if m.IsPhoto() {
+			photos = append(photos, m.Photo())
+		}
+	}
+	return photos, nil
+}
+
+// GetCollectionVideos retrieves the videos of a collection from the Pexels API.
+// It takes a context, an ID, and GetCollectionMediaParams as input and returns a list of videos and an error.
+// Type is set to "videos" on a copy of params, and each media item is converted with CollectionMedia.Video.
+// Only the requested page is returned; use NextCollectionMediaPage or AllCollectionMedia to walk further.
+func (c *Client) GetCollectionVideos(ctx context.Context, id string, params *GetCollectionMediaParams) ([]Video, error) {
+	resp, err := c.getCollectionOfType(ctx, id, params, "videos")
+	if err != nil {
+		return nil, err
+	}
+	var videos []Video
+	for _, m := range resp.Media {
+		if m.IsVideo() {
+			videos = append(videos, m.Video())
+		}
+	}
+	return videos, nil
+}
+
+// getCollectionOfType retrieves a page of a collection's media restricted to mediaType, leaving params untouched.
+func (c *Client) getCollectionOfType(ctx context.Context, id string, params *GetCollectionMediaParams, mediaType string) (*GetCollectionMedia, error) {
+	p := GetCollectionMediaParams{}
+	if params != nil {
+		p = *params
+	}
+	p.Type = mediaType
+	return c.GetCollection(ctx, &p, id)
+}
@@ -0,0 +1,143 @@
+package pexels
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestAllCollectionMedia(t *testing.T) {
+	server := newPagedServer(7, 3)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+
+	// Walk every page
+	media, err := client.AllCollectionMedia(context.Background(), "abc", &GetCollectionMediaParams{PerPage: Int(3)}, 0)
+	if err != nil {
+		t.Fatalf("AllCollectionMedia failed: %v", err)
+	}
+	if len(media) != 7 {
+		t.Fatalf("AllCollectionMedia failed: expected 7 media, got %d", len(media))
+	}
+	for i, m := range media {
+		if m.ID != i+1 {
+			t.Errorf("media[%d].ID = %d, want %d", i, m.ID, i+1)
+		}
+	}
+
+	// Stop once maxItems is reached
+	media, err = client.AllCollectionMedia(context.Background(), "abc", &GetCollectionMediaParams{PerPage: Int(3)}, 4)
+	if err != nil {
+		t.Fatalf("AllCollectionMedia failed: %v", err)
+	}
+	if len(media) != 4 {
+		t.Errorf("AllCollectionMedia failed: expected 4 media, got %d", len(media))
+	}
+}
+
+func TestAllCollectionMediaPartialError(t *testing.T) {
+	server := newPagedServer(7, 3, 2)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	media, err := client.AllCollectionMedia(context.Background(), "abc", &GetCollectionMediaParams{PerPage: Int(3)}, 0)
+	if err == nil {
+		t.Fatal("AllCollectionMedia failed: expected an error")
+	}
+	if len(media) != 3 {
+		t.Errorf("AllCollectionMedia failed: expected the 3 media of the first page, got %d", len(media))
+	}
+}
+
+func TestGetCollectionInfo(t *testing.T) {
+	server := newPagedServer(7, 3)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	info, err := client.GetCollectionInfo(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("GetCollectionInfo failed: %v", err)
+	}
+	if info.ID != "abc" || info.MediaCount != 7 {
+		t.Errorf("GetCollectionInfo failed: got %+v", info)
+	}
+}
+
+func TestCollectionMediaType(t *testing.T) {
+	tests := []struct {
+		json         string
+		photo, video bool
+	}{
+		{`{"type":"Photo"}`, true, false},
+		{`{"type":"photo"}`, true, false},
+		{`{"type":"Video"}`, false, true},
+		{`{"type":"VIDEO"}`, false, true},
+		{`{"type":""}`, false, false},
+		{`{"type":"Audio"}`, false, false},
+	}
+	for _, tt := range tests {
+		var m CollectionMedia
+		if err := json.Unmarshal([]byte(tt.json), &m); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if m.IsPhoto() != tt.photo || m.IsVideo() != tt.video {
+			t.Errorf("%s: IsPhoto() = %v, IsVideo() = %v, want %v, %v", tt.json, m.IsPhoto(), m.IsVideo(), tt.photo, tt.video)
+		}
+	}
+}
+
+func TestGetCollectionPhotosAndVideos(t *testing.T) {
+	const body = `{"id":"abc","media":[
+		{"type":"Photo","id":1,"width":400,"photographer":"Ann","avg_color":"#000000","src":{"tiny":"t.jpg"}},
+		{"type":"Video","id":2,"duration":12,"image":"i.jpg","video_files":[{"id":20,"link":"v.mp4"}]}
+	]}`
+	var req *http.Request
+	client := newStubClient(body, &req)
+	params := &GetCollectionMediaParams{PerPage: Int(10)}
+
+	photos, err := client.GetCollectionPhotos(context.Background(), "abc", params)
+	if err != nil {
+		t.Fatalf("GetCollectionPhotos failed: %v", err)
+	}
+	if got := req.URL.Query().Get("type"); got != "photos" {
+		t.Errorf("type = %q, want photos", got)
+	}
+	if len(photos) != 1 || photos[0].ID != 1 || photos[0].Width != 400 || photos[0].Photographer != "Ann" || photos[0].Src.Tiny != "t.jpg" {
+		t.Errorf("GetCollectionPhotos = %+v", photos)
+	}
+
+	videos, err := client.GetCollectionVideos(context.Background(), "abc", params)
+	if err != nil {
+		t.Fatalf("GetCollectionVideos failed: %v", err)
+	}
+	if got := req.URL.Query().Get("type"); got != "videos" {
+		t.Errorf("type = %q, want videos", got)
+	}
+	if len(videos) != 1 || videos[0].ID != 2 || videos[0].Duration != 12 || videos[0].Image != "i.jpg" || len(videos[0].VideoFiles) != 1 {
+		t.Errorf("GetCollectionVideos = %+v", videos)
+	}
+	if params.Type != "" {
+		t.Errorf("params.Type = %q, want the caller's params untouched", params.Type)
+	}
+}
+
+func TestPublicOnly(t *testing.T) {
+	resp := GetCollectionsResponse{Collections: []Collection{
+		{ID: "a", Private: true},
+		{ID: "b"},
+		{ID: "c", Private: true},
+		{ID: "d"},
+	}}
+	public := resp.PublicOnly()
+	if len(public) != 2 || public[0].ID != "b" || public[1].ID != "d" {
+		t.Errorf("PublicOnly() = %+v, want collections b and d", public)
+	}
+	if len(resp.Collections) != 4 {
+		t.Errorf("PublicOnly modified the response")
+	}
+	if got := (GetCollectionsResponse{}).PublicOnly(); len(got) != 0 {
+		t.Errorf("PublicOnly() of an empty page = %+v", got)
+	}
+}
@@ -0,0 +1,42 @@
+package pexels
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// decompress decodes a response body according to its Content-Encoding header.
+// Go's transport normally decompresses gzip transparently and removes the header, but custom transports may not.
+// Deflate bodies are accepted both zlib-wrapped, as the HTTP specification requires, and raw, as some servers send them.
+// Unknown or identity encodings return the body unchanged. The decompressed size is bounded by limit as in readLimited.
+func decompress(encoding string, body []byte, limit int64) ([]byte, error) {
+	var r io.Reader
+	var err error
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "gzip", "x-gzip":
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case "deflate":
+		r, err = zlib.NewReader(bytes.NewReader(body))
+		if errors.Is(err, zlib.ErrHeader) {
+			r, err = flate.NewReader(bytes.NewReader(body)), nil
+		}
+	default:
+		return body, nil
+	}
+	if err == nil {
+		body, err = readLimited(r, limit)
+	}
+	if errors.Is(err, ErrResponseTooLarge) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pexels: decompressing %s response: %w", encoding, err)
+	}
+	return body, nil
+}
@@ -0,0 +1,66 @@
+package pexels
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompressedResponses(t *testing.T) {
+	const body = `{"page":1,"per_page":1,"total_results":1,"photos":[{"id":42}]}`
+	tests := []struct {
+		encoding string
+		writer   func(io.Writer) io.WriteCloser
+	}{
+		{"gzip", func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }},
+		{"deflate", func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) }},
+		{"deflate", func(w io.Writer) io.WriteCloser { fw, _ := flate.NewWriter(w, flate.DefaultCompression); return fw }},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		zw := tt.writer(&buf)
+		zw.Write([]byte(body))
+		zw.Close()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", tt.encoding)
+			w.Write(buf.Bytes())
+		}))
+		// A transport without transparent decompression leaves the body compressed
+		client := NewClient("key", WithBaseURL(server.URL+"/"), WithTransport(&http.Transport{DisableCompression: true}))
+		resp, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{})
+		server.Close()
+		if err != nil {
+			t.Fatalf("GetCurated with %s body failed: %v", tt.encoding, err)
+		}
+		if len(resp.Photos) != 1 || resp.Photos[0].ID != 42 {
+			t.Errorf("GetCurated with %s body returned %+v", tt.encoding, resp)
+		}
+	}
+}
+
+func TestCompressedResponseLimit(t *testing.T) {
+	// A small gzip body expanding beyond the limit is rejected
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(bytes.Repeat([]byte(" "), 1<<20))
+	zw.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithTransport(&http.Transport{DisableCompression: true}), WithMaxResponseBytes(64<<10))
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("GetCurated = %v, want ErrResponseTooLarge", err)
+	}
+}
@@ -0,0 +1,40 @@
+package pexels
+
+import "strconv"
+
+// Key returns the ID of the photo as a string, suitable as a map key or cache key.
+func (p Photo) Key() string {
+	return strconv.Itoa(p.ID)
+}
+
+// Key returns the ID of the video as a string, suitable as a map key or cache key.
+func (v Video) Key() string {
+	return strconv.Itoa(v.ID)
+}
+
+// DedupePhotos returns the photos with repeated IDs removed, keeping the first occurrence and the original order.
+// It is useful when merging pages of overlapping queries. The input slice is not modified.
+func DedupePhotos(photos []Photo) []Photo {
+	return dedupeByID(photos, func(p Photo) int { return p.ID })
+}
+
+// DedupeVideos returns the videos with repeated IDs removed, keeping the first occurrence and the original order.
+// It is useful when merging pages of overlapping queries. The input slice is not modified.
+func DedupeVideos(videos []Video) []Video {
+	return dedupeByID(videos, func(v Video) int { return v.ID })
+}
+
+// dedupeByID returns items without those whose id was already seen, keeping the first occurrence and the order.
+func dedupeByID[T any](items []T, id func(T) int) []T {
+	seen := make(map[int]struct{}, len(items))
+	unique := make([]T, 0, len(items))
+	for _, item := range items {
+		key := id(item)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, item)
+	}
+	return unique
+}
@@ -0,0 +1,29 @@
+package pexels
+
+import "testing"
+
+func TestDedupe(t *testing.T) {
+	photos := DedupePhotos([]Photo{{ID: 3}, {ID: 1}, {ID: 3}, {ID: 2}, {ID: 1}})
+	videos := DedupeVideos([]Video{{ID: 3}, {ID: 1}, {ID: 3}, {ID: 2}, {ID: 1}})
+	want := []int{3, 1, 2}
+	if len(photos) != len(want) || len(videos) != len(want) {
+		t.Fatalf("got %d photos and %d videos, want %d", len(photos), len(videos), len(want))
+	}
+	for i, id := range want {
+		if photos[i].ID != id || videos[i].ID != id {
+			t.Errorf("item %d = photo %d, video %d, want %d", i, photos[i].ID, videos[i].ID, id)
+		}
+	}
+	if len(DedupePhotos(nil)) != 0 {
+		t.Errorf("DedupePhotos(nil) is not empty")
+	}
+}
+
+func TestKey(t *testing.T) {
+	if got := (Photo{ID: 2014422}).Key(); got != "2014422" {
+		t.Errorf("Photo.Key() = %q, want 2014422", got)
+	}
+	if got := (Video{ID: 857251}).Key(); got != "857251" {
+		t.Errorf("Video.Key() = %q, want 857251", got)
+	}
+}
@@ -0,0 +1,210 @@
+package pexels
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// DownloadPhoto downloads a photo from the Pexels CDN.
+// It takes a context, the URL of one of the sizes in a PhotoSrc, and a writer as input and returns the number of bytes written and an error.
+// The photo is streamed into the writer; the API key is not sent since CDN assets do not require it.
+func (c *Client) DownloadPhoto(ctx context.Context, src string, w io.Writer) (int64, error) {
+	req, err := c.newDownloadRequest(ctx, src)
+	if err != nil {
+		return 0, err
+	}
+	return c.download(req, w)
+}
+
+// DownloadOriginal downloads a photo at its original resolution from the Pexels CDN.
+// It takes a context, a Photo, and a writer as input and returns the number of bytes written and an error.
+// An error is returned without a request if the photo has no original URL.
+func (c *Client) DownloadOriginal(ctx context.Context, p Photo, w io.Writer) (int64, error) {
+	return c.DownloadSize(ctx, p, "original", w)
+}
+
+// DownloadSize downloads a photo in the named size from the Pexels CDN.
+// It takes a context, a Photo, a size name from PhotoSrcNames, and a writer as input and returns the number of bytes written and an error.
+// An error is returned without a request if the size name is unknown or the photo has no URL for it.
+func (c *Client) DownloadSize(ctx context.Context, p Photo, size string, w io.Writer) (int64, error) {
+	src, ok := p.Src.ByName(size)
+	if !ok {
+		return 0, fmt.Errorf("pexels: unknown photo size %q, must be one of %s", size, strings.Join(PhotoSrcNames, ", "))
+	}
+	if src == "" {
+		return 0, fmt.Errorf("pexels: photo %d has no %s URL", p.ID, strings.ToLower(size))
+	}
+	return c.DownloadPhoto(ctx, src, w)
+}
+
+// DownloadPhotoToFile downloads a photo from the Pexels CDN into a file.
+// It takes a context, the URL of one of the sizes in a PhotoSrc, and a file path as input and returns the number of bytes written and an error.
+// The file is created or truncated, and removed again if the download fails.
+func (c *Client) DownloadPhotoToFile(ctx context.Context, src, path string) (int64, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	n, err := c.DownloadPhoto(ctx, src, f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return n, err
+	}
+	return n, nil
+}
+
+// DownloadVideoFile downloads a video file from the Pexels CDN.
+// It takes a context, a VideoFile, and a writer as input and returns the number of bytes written and an error.
+// The file at f.Link is streamed into the writer.
+// Large files may outlive the client timeout; create the client with WithTimeout(0) and bound the download with ctx instead.
+func (c *Client) DownloadVideoFile(ctx context.Context, f VideoFile, w io.Writer) (int64, error) {
+	return c.DownloadVideoFileFrom(ctx, f, 0, w)
+}
+
+// DownloadVideoFileFrom downloads a video file from the Pexels CDN starting at the given byte offset.
+// It takes a context, a VideoFile, a start offset, and a writer as input and returns the number of bytes written and an error.
+// A non-zero offset sends a Range header so an interrupted download can be resumed by appending to the partial file.
+// If the server ignores the range and sends the whole file, the first offset bytes are skipped.
+func (c *Client) DownloadVideoFileFrom(ctx context.Context, f VideoFile, offset int64, w io.Writer) (int64, error) {
+	return c.downloadVideoFile(ctx, f, offset, w, nil)
+}
+
+// DownloadVideoFileWithProgress downloads a video file from the Pexels CDN, reporting progress as it goes.
+// It takes a context, a VideoFile, a writer, and a progress callback as input and returns the number of bytes written and an error.
+// onProgress is called once before the first byte with done set to 0, then after every chunk written to w, with the
+// bytes written so far and the total size from the Content-Length header, or -1 if the server did not send one.
+// It runs on the calling goroutine, so a slow callback slows the download. Cancelling ctx stops the download
+// mid-stream and returns the context error along with the bytes written so far.
+func (c *Client) DownloadVideoFileWithProgress(ctx context.Context, f VideoFile, w io.Writer, onProgress func(done, total int64)) (int64, error) {
+	return c.downloadVideoFile(ctx, f, 0, w, onProgress)
+}
+
+// downloadVideoFile downloads f from offset into w, reporting progress to onProgress if it is not nil.
+func (c *Client) downloadVideoFile(ctx context.Context, f VideoFile, offset int64, w io.Writer, onProgress func(done, total int64)) (int64, error) {
+	if f.Link == "" {
+		return 0, fmt.Errorf("pexels: video file %d has no link", f.ID)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("pexels: invalid download offset %d", offset)
+	}
+	req, err := c.newDownloadRequest(ctx, f.Link)
+	if err != nil {
+		return 0, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	return c.downloadFrom(req, offset, w, onProgress)
+}
+
+// progressWriter wraps a download destination, reporting the bytes written to onProgress and failing once ctx is done.
+type progressWriter struct {
+	ctx        context.Context
+	w          io.Writer
+	onProgress func(done, total int64)
+	done       int64 // Bytes written so far
+	total      int64 // Expected size of the download, -1 if unknown
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	if err := pw.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := pw.w.Write(p)
+	pw.done += int64(n)
+	pw.onProgress(pw.done, pw.total)
+	return n, err
+}
+
+// CheckURL checks whether a media URL is still live by sending a HEAD request, which is cheaper than a download.
+// It takes a context and a URL as input and returns whether the response status is 2xx and an error.
+// A non-2xx status returns false with a nil error; the error is only set when no response was received.
+// The request goes through the client's Doer, sharing its transport, timeout, and redirect handling.
+// The API key is only sent when the URL's host matches BaseURL: CDN assets such as PhotoSrc and VideoFile links are
+// checked without it, like downloads, so the key never leaves the API host.
+func (c *Client) CheckURL(ctx context.Context, link string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, link, nil)
+	if err != nil {
+		return false, err
+	}
+	if c.isAPIHost(req.URL) {
+		if req, err = c.newRequest(ctx, http.MethodHead, link); err != nil {
+			return false, err
+		}
+	} else {
+		req.Header.Set("User-Agent", c.userAgentValue())
+	}
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		closeResponse(res)
+		return false, err
+	}
+	drainAndClose(res.Body)
+	return res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices, nil
+}
+
+// isAPIHost reports whether u points at the scheme and host of BaseURL, and may thus carry the API key.
+func (c *Client) isAPIHost(u *url.URL) bool {
+	base, err := url.Parse(c.BaseURL)
+	return err == nil && base.Host != "" && strings.EqualFold(base.Host, u.Host) && strings.EqualFold(base.Scheme, u.Scheme)
+}
+
+// newDownloadRequest creates a GET request for a media asset.
+// Unlike newRequest it does not set the Authorization header.
+func (c *Client) newDownloadRequest(ctx context.Context, link string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgentValue())
+	return req, nil
+}
+
+// download sends a download request and streams the response body into w.
+// It returns the number of bytes written and an APIError for non-2xx responses.
+func (c *Client) download(req *http.Request, w io.Writer) (int64, error) {
+	return c.downloadFrom(req, 0, w, nil)
+}
+
+// downloadFrom is like download but expects the body to start at offset.
+// When the server answers a ranged request with the full content instead of 206 Partial Content, the first offset bytes are discarded.
+// If onProgress is not nil, it is called as the body is written to w; see DownloadVideoFileWithProgress.
+func (c *Client) downloadFrom(req *http.Request, offset int64, w io.Writer, onProgress func(done, total int64)) (int64, error) {
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		closeResponse(res)
+		return 0, err
+	}
+	defer drainAndClose(res.Body)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		// Error pages can come from a CDN rather than the API, so only their head is kept
+		bytes, err := io.ReadAll(io.LimitReader(res.Body, maxDrainBytes))
+		if err != nil {
+			return 0, err
+		}
+		return 0, newAPIError(res, bytes, c.timeSource().Now())
+	}
+	total := res.ContentLength
+	if offset > 0 && res.StatusCode != http.StatusPartialContent {
+		if _, err := io.CopyN(io.Discard, res.Body, offset); err != nil {
+			return 0, err
+		}
+		if total >= 0 {
+			total -= offset
+		}
+	}
+	if onProgress != nil {
+		w = &progressWriter{ctx: req.Context(), w: w, onProgress: onProgress, total: total}
+		onProgress(0, total)
+	}
+	return io.Copy(w, res.Body)
+}
@@ -0,0 +1,315 @@
+package pexels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newAssetServer returns a server that serves payload at /asset and 404s everywhere else.
+func newAssetServer(payload []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/asset" {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, "asset", time.Time{}, bytes.NewReader(payload))
+	}))
+}
+
+func TestDownloadPhoto(t *testing.T) {
+	payload := []byte("jpeg bytes")
+	server := newAssetServer(payload)
+	defer server.Close()
+
+	client := NewClient("key")
+
+	// Download the photo into a buffer
+	var buf bytes.Buffer
+	n, err := client.DownloadPhoto(context.Background(), server.URL+"/asset", &buf)
+	if err != nil {
+		t.Fatalf("DownloadPhoto failed: %v", err)
+	}
+	if n != int64(len(payload)) || !bytes.Equal(buf.Bytes(), payload) {
+		t.Errorf("DownloadPhoto failed: got %d bytes %q", n, buf.Bytes())
+	}
+
+	// A missing photo must return an APIError
+	_, err = client.DownloadPhoto(context.Background(), server.URL+"/missing", &buf)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("DownloadPhoto failed: expected 404 *APIError, got %v", err)
+	}
+}
+
+func TestDownloadLargeErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write(bytes.Repeat([]byte("x"), 4*maxDrainBytes))
+	}))
+	defer server.Close()
+
+	_, err := NewClient("key").DownloadPhoto(context.Background(), server.URL+"/asset", io.Discard)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("DownloadPhoto failed: expected 502 *APIError, got %v", err)
+	}
+	if len(apiErr.Message) != maxDrainBytes {
+		t.Errorf("APIError.Message holds %d bytes, want it capped at %d", len(apiErr.Message), maxDrainBytes)
+	}
+}
+
+func TestDownloadPhotoToFile(t *testing.T) {
+	payload := []byte("jpeg bytes")
+	server := newAssetServer(payload)
+	defer server.Close()
+
+	client := NewClient("key")
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "photo.jpeg")
+	if _, err := client.DownloadPhotoToFile(context.Background(), server.URL+"/asset", path); err != nil {
+		t.Fatalf("DownloadPhotoToFile failed: %v", err)
+	}
+	if got, err := os.ReadFile(path); err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("DownloadPhotoToFile failed: file contains %q, %v", got, err)
+	}
+
+	// A failed download must not leave a partial file behind
+	missing := filepath.Join(dir, "missing.jpeg")
+	if _, err := client.DownloadPhotoToFile(context.Background(), server.URL+"/missing", missing); err == nil {
+		t.Errorf("DownloadPhotoToFile failed: expected an error")
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Errorf("DownloadPhotoToFile failed: partial file left behind")
+	}
+}
+
+func TestDownloadVideoFile(t *testing.T) {
+	payload := []byte("0123456789 mp4 bytes")
+	server := newAssetServer(payload)
+	defer server.Close()
+
+	client := NewClient("key")
+	file := VideoFile{ID: 1, Link: server.URL + "/asset"}
+
+	// Download the whole file
+	var buf bytes.Buffer
+	n, err := client.DownloadVideoFile(context.Background(), file, &buf)
+	if err != nil {
+		t.Fatalf("DownloadVideoFile failed: %v", err)
+	}
+	if n != int64(len(payload)) || !bytes.Equal(buf.Bytes(), payload) {
+		t.Errorf("DownloadVideoFile failed: got %d bytes %q", n, buf.Bytes())
+	}
+
+	// Resume from an offset using a ranged request
+	buf.Reset()
+	n, err = client.DownloadVideoFileFrom(context.Background(), file, 10, &buf)
+	if err != nil {
+		t.Fatalf("DownloadVideoFileFrom failed: %v", err)
+	}
+	if n != int64(len(payload)-10) || !bytes.Equal(buf.Bytes(), payload[10:]) {
+		t.Errorf("DownloadVideoFileFrom failed: got %d bytes %q", n, buf.Bytes())
+	}
+
+	// An offset past the end of the file is not satisfiable
+	_, err = client.DownloadVideoFileFrom(context.Background(), file, 100, &buf)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		t.Errorf("DownloadVideoFileFrom failed: expected 416 *APIError, got %v", err)
+	}
+}
+
+func TestDownloadVideoFileRangeIgnored(t *testing.T) {
+	payload := []byte("0123456789 mp4 bytes")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Ignore the Range header and always send the full file
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	client := NewClient("key")
+	var buf bytes.Buffer
+	n, err := client.DownloadVideoFileFrom(context.Background(), VideoFile{Link: server.URL}, 10, &buf)
+	if err != nil {
+		t.Fatalf("DownloadVideoFileFrom failed: %v", err)
+	}
+	if n != int64(len(payload)-10) || !bytes.Equal(buf.Bytes(), payload[10:]) {
+		t.Errorf("DownloadVideoFileFrom failed: got %d bytes %q", n, buf.Bytes())
+	}
+}
+
+func TestDownloadOriginalAndSize(t *testing.T) {
+	payload := []byte("jpeg bytes")
+	server := newAssetServer(payload)
+	defer server.Close()
+
+	client := NewClient("key")
+	photo := Photo{ID: 1, Src: PhotoSrc{Original: server.URL + "/asset", Medium: server.URL + "/asset"}}
+
+	var buf bytes.Buffer
+	if n, err := client.DownloadOriginal(context.Background(), photo, &buf); err != nil || n != int64(len(payload)) {
+		t.Fatalf("DownloadOriginal = %d, %v", n, err)
+	}
+	buf.Reset()
+	if n, err := client.DownloadSize(context.Background(), photo, "Medium", &buf); err != nil || n != int64(len(payload)) {
+		t.Fatalf("DownloadSize(Medium) = %d, %v", n, err)
+	}
+
+	// Unknown sizes and missing URLs fail without a request
+	for _, size := range []string{"huge", "tiny"} {
+		if _, err := client.DownloadSize(context.Background(), photo, size, &buf); err == nil {
+			t.Errorf("DownloadSize(%q) succeeded, want an error", size)
+		}
+	}
+	if _, err := client.DownloadOriginal(context.Background(), Photo{ID: 2}, &buf); err == nil {
+		t.Errorf("DownloadOriginal succeeded without an original URL")
+	}
+}
+
+func TestDownloadVideoFileWithProgress(t *testing.T) {
+	payload := bytes.Repeat([]byte("mp4 bytes "), 10000)
+	server := newAssetServer(payload)
+	defer server.Close()
+
+	client := NewClient("key")
+	var calls, lastDone, lastTotal int64
+	var buf bytes.Buffer
+	n, err := client.DownloadVideoFileWithProgress(context.Background(), VideoFile{Link: server.URL + "/asset"}, &buf, func(done, total int64) {
+		if done < lastDone {
+			t.Errorf("progress went back from %d to %d", lastDone, done)
+		}
+		calls++
+		lastDone, lastTotal = done, total
+	})
+	if err != nil {
+		t.Fatalf("DownloadVideoFileWithProgress failed: %v", err)
+	}
+	if n != int64(len(payload)) || !bytes.Equal(buf.Bytes(), payload) {
+		t.Errorf("DownloadVideoFileWithProgress failed: got %d bytes", n)
+	}
+	if calls < 2 || lastDone != n || lastTotal != n {
+		t.Errorf("got %d progress calls ending at %d/%d, want at least 2 ending at %d/%d", calls, lastDone, lastTotal, n, n)
+	}
+}
+
+func TestDownloadVideoFileWithProgressUnknownLength(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Flushing before the end forces a chunked response without Content-Length
+		w.Write([]byte("first "))
+		w.(http.Flusher).Flush()
+		w.Write([]byte("second"))
+	}))
+	defer server.Close()
+
+	client := NewClient("key")
+	var lastDone, lastTotal int64
+	n, err := client.DownloadVideoFileWithProgress(context.Background(), VideoFile{Link: server.URL}, io.Discard, func(done, total int64) {
+		lastDone, lastTotal = done, total
+	})
+	if err != nil {
+		t.Fatalf("DownloadVideoFileWithProgress failed: %v", err)
+	}
+	if lastDone != n || n != 12 || lastTotal != -1 {
+		t.Errorf("progress ended at %d/%d after %d bytes, want 12/-1", lastDone, lastTotal, n)
+	}
+}
+
+func TestDownloadVideoFileWithProgressCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient("key")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n, err := client.DownloadVideoFileWithProgress(ctx, VideoFile{Link: server.URL}, io.Discard, func(done, total int64) {
+		if done > 0 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DownloadVideoFileWithProgress error = %v, want context.Canceled", err)
+	}
+	if n != 7 {
+		t.Errorf("DownloadVideoFileWithProgress wrote %d bytes before cancellation, want 7", n)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	var methods []string
+	var auth []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		auth = append(auth, r.Header.Get("Authorization"))
+		if r.URL.Path != "/asset" {
+			http.NotFound(w, r)
+		}
+	})
+	cdn := httptest.NewServer(handler)
+	defer cdn.Close()
+	api := httptest.NewServer(handler)
+	defer api.Close()
+
+	client := NewClient("key", WithBaseURL(api.URL+"/"))
+	tests := []struct {
+		url      string
+		wantLive bool
+		wantAuth string
+	}{
+		{cdn.URL + "/asset", true, ""},
+		{cdn.URL + "/gone", false, ""},
+		{api.URL + "/asset", true, "key"},
+	}
+	for _, tt := range tests {
+		methods, auth = nil, nil
+		live, err := client.CheckURL(context.Background(), tt.url)
+		if err != nil || live != tt.wantLive {
+			t.Errorf("CheckURL(%s) = %v, %v, want %v", tt.url, live, err, tt.wantLive)
+		}
+		if len(methods) != 1 || methods[0] != http.MethodHead || auth[0] != tt.wantAuth {
+			t.Errorf("CheckURL(%s) sent %v with Authorization %q, want one HEAD with %q", tt.url, methods, auth, tt.wantAuth)
+		}
+	}
+
+	// A connection failure is an error rather than a dead link
+	cdn.Close()
+	if _, err := client.CheckURL(context.Background(), cdn.URL+"/asset"); err == nil {
+		t.Errorf("CheckURL on a closed server returned no error")
+	}
+}
+
+func TestPhotoHandle(t *testing.T) {
+	payload := []byte("jpeg bytes")
+	server := newAssetServer(payload)
+	defer server.Close()
+
+	photo := Photo{ID: 1, Src: PhotoSrc{Large: server.URL + "/asset"}}
+	handle := NewClient("key").Photo(photo)
+	if got := handle.URL("large"); got != photo.Src.Large {
+		t.Errorf("URL(large) = %q, want %q", got, photo.Src.Large)
+	}
+	if got := handle.URL("small"); got != "" {
+		t.Errorf("URL(small) = %q, want empty", got)
+	}
+
+	var buf bytes.Buffer
+	if n, err := handle.Download(context.Background(), "large", &buf); err != nil || n != int64(len(payload)) {
+		t.Fatalf("Download(large) = %d, %v", n, err)
+	}
+	if _, err := handle.Download(context.Background(), "huge", &buf); err == nil {
+		t.Errorf("Download(huge) succeeded, want an unknown size error")
+	}
+}
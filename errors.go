@@ -0,0 +1,118 @@
+package pexels
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrRateLimited is returned (wrapped in an APIError) when the Pexels API responds with 429 Too Many Requests.
+var ErrRateLimited = errors.New("pexels: rate limited")
+
+// ErrInvalidAPIKey is returned before any request is made when the client's API key is empty or malformed.
+// An APIError for a 401 Unauthorized response also matches it with errors.Is.
+var ErrInvalidAPIKey = errors.New("pexels: invalid API key")
+
+// ErrUnauthorized is returned (wrapped in an APIError) when the Pexels API responds with 401 Unauthorized,
+// meaning the API key was rejected and a new one is needed.
+var ErrUnauthorized = errors.New("pexels: unauthorized")
+
+// ErrForbidden is returned (wrapped in an APIError) when the Pexels API responds with 403 Forbidden,
+// meaning the key is valid but not allowed to access the resource.
+var ErrForbidden = errors.New("pexels: forbidden")
+
+// ErrInvalidID is returned before any request is made when a photo or video ID is empty or not numeric.
+var ErrInvalidID = errors.New("pexels: invalid id")
+
+// ErrResponseTooLarge is returned when a response body exceeds the limit set with WithMaxResponseBytes.
+var ErrResponseTooLarge = errors.New("pexels: response too large")
+
+// APIError represents a non-2xx response from the Pexels API.
+type APIError struct {
+	StatusCode int           // HTTP status code of the response
+	Message    string        // Reason given in a structured error body, or the raw body when it has none
+	Code       string        // Machine-readable error code from a structured error body, if any
+	RetryAfter time.Duration // Time to wait before retrying, taken from the Retry-After header
+	ResetAt    time.Time     // Time at which the monthly quota resets, taken from the X-Ratelimit-Reset header; informational only
+	err        error         // Sentinel error the response maps to, if any
+}
+
+// Error returns a description of the API error.
+func (e *APIError) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("%v: %d %s", e.err, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("Unknown API error: %d %s", e.StatusCode, e.Message)
+}
+
+// Unwrap returns the sentinel error the response maps to, so that errors.Is can be used on it.
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether a 401 response is being matched against ErrInvalidAPIKey, which it also maps to.
+func (e *APIError) Is(target error) bool {
+	return target == ErrInvalidAPIKey && e.err == ErrUnauthorized
+}
+
+// errorResponse is the structured body the Pexels API sends with some error responses,
+// e.g. {"status":404,"code":"not_found","error":"Not Found"}.
+type errorResponse struct {
+	Status  int    `json:"status"` // HTTP status code, repeated in the body
+	Code    string `json:"code"`   // Machine-readable error code
+	Message string `json:"error"`  // Human-readable reason
+}
+
+// newAPIError builds an APIError from an HTTP response and its already read body.
+// now is the current time of the client's Clock, against which a Retry-After date is measured.
+// A structured error body fills in Message and Code; any other body is kept as the Message text.
+func newAPIError(res *http.Response, body []byte, now time.Time) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Message:    string(body),
+		RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), now),
+	}
+	var structured errorResponse
+	if err := json.Unmarshal(body, &structured); err == nil && structured.Message != "" {
+		apiErr.Message = structured.Message
+		apiErr.Code = structured.Code
+	}
+	switch res.StatusCode {
+	case http.StatusUnauthorized:
+		apiErr.err = ErrUnauthorized
+	case http.StatusForbidden:
+		apiErr.err = ErrForbidden
+	case http.StatusTooManyRequests:
+		apiErr.err = ErrRateLimited
+		apiErr.ResetAt = parseRateLimitReset(res.Header.Get("X-Ratelimit-Reset"))
+	}
+	return apiErr
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date, measured from now.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// parseRateLimitReset parses the X-Ratelimit-Reset header, a UNIX timestamp in seconds.
+func parseRateLimitReset(v string) time.Time {
+	secs, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || secs <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
@@ -0,0 +1,171 @@
+package pexels
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedError(t *testing.T) {
+	// Set up a server that always responds with 429
+	reset := time.Now().Add(time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.Header().Set("X-Ratelimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	// Initialize a new Pexels API client pointed at the server
+	client := NewClient("key")
+	client.BaseURL = server.URL + "/"
+
+	// Call the GetCurated function
+	_, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("GetCurated failed: expected ErrRateLimited, got %v", err)
+	}
+
+	// Check the rate limit info attached to the error
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetCurated failed: expected *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusTooManyRequests)
+	}
+	if apiErr.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter = %v, want 30s", apiErr.RetryAfter)
+	}
+	if apiErr.ResetAt.Unix() != reset {
+		t.Errorf("ResetAt = %v, want %v", apiErr.ResetAt.Unix(), reset)
+	}
+}
+
+func TestAPIErrorNotRateLimited(t *testing.T) {
+	// Set up a server that always responds with 404
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient("key")
+	client.BaseURL = server.URL + "/"
+
+	_, err := client.GetPhoto(context.Background(), "1")
+	if errors.Is(err, ErrRateLimited) {
+		t.Errorf("GetPhoto failed: 404 should not be ErrRateLimited")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("GetPhoto failed: expected 404 *APIError, got %v", err)
+	}
+}
+
+func TestAuthErrors(t *testing.T) {
+	tests := []struct {
+		status  int
+		body    string
+		reason  string
+		want    error
+		notWant error
+	}{
+		{http.StatusUnauthorized, `{"error":"Invalid API key"}`, "Invalid API key", ErrUnauthorized, ErrForbidden},
+		{http.StatusForbidden, `{"error":"Access denied"}`, "Access denied", ErrForbidden, ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		client := NewClient("key", WithHTTPClient(&fakeDoer{status: tt.status, body: tt.body}))
+		_, err := client.GetPhoto(context.Background(), "1")
+		if !errors.Is(err, tt.want) || errors.Is(err, tt.notWant) {
+			t.Errorf("%d: error = %v, want %v only", tt.status, err, tt.want)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+			t.Errorf("%d: expected *APIError, got %v", tt.status, err)
+		}
+		if !strings.Contains(err.Error(), tt.reason) {
+			t.Errorf("%d: error %q does not include the server message", tt.status, err)
+		}
+	}
+
+	// A rejected key still matches ErrInvalidAPIKey, a forbidden resource does not
+	client := NewClient("key", WithHTTPClient(&fakeDoer{status: http.StatusUnauthorized}))
+	if _, err := client.GetPhoto(context.Background(), "1"); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("401 error = %v, want it to match ErrInvalidAPIKey", err)
+	}
+	client = NewClient("key", WithHTTPClient(&fakeDoer{status: http.StatusForbidden}))
+	if _, err := client.GetPhoto(context.Background(), "1"); errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("403 error = %v, want it not to match ErrInvalidAPIKey", err)
+	}
+}
+
+func TestStructuredErrorBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantMessage string
+		wantCode    string
+	}{
+		{"structured", `{"status":400,"code":"invalid_parameter","error":"Invalid per_page value"}`, "Invalid per_page value", "invalid_parameter"},
+		{"error only", `{"error":"Not Found"}`, "Not Found", ""},
+		{"other JSON", `{"detail":"nope"}`, `{"detail":"nope"}`, ""},
+		{"plain text", "Bad Request", "Bad Request", ""},
+	}
+	for _, tt := range tests {
+		client := NewClient("key", WithHTTPClient(&fakeDoer{status: http.StatusBadRequest, body: tt.body}))
+		_, err := client.GetPhoto(context.Background(), "1")
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%s: expected *APIError, got %v", tt.name, err)
+		}
+		if apiErr.Message != tt.wantMessage || apiErr.Code != tt.wantCode {
+			t.Errorf("%s: Message = %q, Code = %q, want %q and %q", tt.name, apiErr.Message, apiErr.Code, tt.wantMessage, tt.wantCode)
+		}
+		if !strings.Contains(err.Error(), tt.wantMessage) {
+			t.Errorf("%s: Error() = %q does not include the reason", tt.name, err.Error())
+		}
+	}
+}
+
+func TestRateLimitRetryIgnoresQuotaReset(t *testing.T) {
+	// A 429 with only the monthly reset, 20 days ahead, falls back to the backoff
+	var calls int32
+	reset := time.Now().Add(20 * 24 * time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-Ratelimit-Reset", strconv.FormatInt(reset, 10))
+		if r.URL.Query().Get("query") == "long" {
+			w.Header().Set("Retry-After", "7200")
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Now()}
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithClock(clock), WithRetry(3, time.Second),
+		WithBackoff(func(attempt int) time.Duration { return time.Second }))
+	_, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 0 || apiErr.ResetAt.Unix() != reset {
+		t.Fatalf("GetCurated error = %#v, want a 429 with ResetAt and no RetryAfter", err)
+	}
+	if calls != 3 || len(clock.sleeps) != 2 || clock.sleeps[0] != time.Second || clock.sleeps[1] != time.Second {
+		t.Errorf("got %d calls and sleeps %v, want 3 calls and [1s 1s]", calls, clock.sleeps)
+	}
+
+	// A Retry-After above the maximum delay returns the error without waiting
+	calls, clock.sleeps = 0, nil
+	_, err = client.GetPhotos(context.Background(), &GetPhotosParams{Query: "long"})
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 2*time.Hour {
+		t.Fatalf("GetPhotos error = %v, want a 429 with a 2h RetryAfter", err)
+	}
+	if calls != 1 || len(clock.sleeps) != 0 {
+		t.Errorf("got %d calls and sleeps %v, want 1 call and no sleep", calls, clock.sleeps)
+	}
+}
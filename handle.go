@@ -0,0 +1,31 @@
+package pexels
+
+import (
+	"context"
+	"io"
+)
+
+// PhotoHandle binds a Photo to the Client that downloads it, as returned by Client.Photo.
+// It is sugar over PhotoSrc.ByName and DownloadSize, which remain available for stateless use.
+type PhotoHandle struct {
+	Photo  Photo   // The photo the handle refers to
+	client *Client // Client used for downloads
+}
+
+// Photo returns a handle binding p to the client, e.g. client.Photo(p).Download(ctx, "large", w).
+func (c *Client) Photo(p Photo) *PhotoHandle {
+	return &PhotoHandle{Photo: p, client: c}
+}
+
+// URL returns the URL of the photo in the named size from PhotoSrcNames, or an empty string if the size name is
+// unknown or the photo has no URL for it.
+func (h *PhotoHandle) URL(size string) string {
+	src, _ := h.Photo.Src.ByName(size)
+	return src
+}
+
+// Download downloads the photo in the named size into w using the bound client.
+// It takes a context, a size name from PhotoSrcNames, and a writer as input and returns the number of bytes written and an error.
+func (h *PhotoHandle) Download(ctx context.Context, size string, w io.Writer) (int64, error) {
+	return h.client.DownloadSize(ctx, h.Photo, size, w)
+}
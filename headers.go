@@ -0,0 +1,29 @@
+package pexels
+
+import (
+	"context"
+	"net/http"
+)
+
+// headersKey is the context key under which ContextWithHeaders stores extra request headers.
+type headersKey struct{}
+
+// ContextWithHeaders returns a copy of ctx that makes client methods add the given headers to their API requests,
+// e.g. a trace ID or a feature flag for a single call, without reconfiguring the client.
+// The headers are applied after the standard ones and replace any standard header of the same name, including
+// User-Agent and the API key header. Calling it again on the returned context replaces the headers rather than
+// merging them. Downloads from the CDN do not send them.
+func ContextWithHeaders(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, headersKey{}, header.Clone())
+}
+
+// headersFromContext returns the headers stored in ctx by ContextWithHeaders, or nil if there are none.
+func headersFromContext(ctx context.Context) http.Header {
+	header, _ := ctx.Value(headersKey{}).(http.Header)
+	return header
+}
+
+// GetPhotosWithHeaders is like GetPhotos but adds extra headers to the request, as described by ContextWithHeaders.
+func (c *Client) GetPhotosWithHeaders(ctx context.Context, params *GetPhotosParams, extra http.Header) (*GetPhotoResponse, error) {
+	return c.GetPhotos(ContextWithHeaders(ctx, extra), params)
+}
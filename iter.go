@@ -0,0 +1,248 @@
+package pexels
+
+import "context"
+
+// pageIterator walks every item of a paginated listing, fetching the next page when the current one is exhausted.
+// It holds the paging logic shared by PhotoIterator, VideoIterator and CollectionMediaIterator.
+type pageIterator[T any] struct {
+	fetch    func() ([]T, bool, error) // Fetches the next page, reporting false when there is none
+	maxPages int                       // Maximum number of pages fetched, 0 for no limit, see LimitPages
+	pages    int                       // Number of pages fetched so far
+	items    []T                       // Items of the current page
+	index    int                       // Index of the next item of the current page
+	item     T                         // Item returned by the last call to next
+	err      error                     // Error that stopped the iteration
+	done     bool                      // Set once the last page has been consumed
+}
+
+// newPageIterator returns an iterator whose first page is fetched by first and whose following pages are fetched by
+// next, one of the NextPage methods of Client. items extracts the items of a page.
+func newPageIterator[R, T any](ctx context.Context, first func() (*R, error), next func(context.Context, *R) (*R, bool, error), items func(*R) []T) pageIterator[T] {
+	var resp *R
+	return pageIterator[T]{fetch: func() ([]T, bool, error) {
+		var err error
+		if resp == nil {
+			resp, err = first()
+		} else {
+			var ok bool
+			if resp, ok, err = next(ctx, resp); !ok {
+				return nil, false, nil
+			}
+		}
+		if err != nil || resp == nil {
+			return nil, false, err
+		}
+		return items(resp), true, nil
+	}}
+}
+
+// next advances the iterator to the next item, fetching a new page if needed.
+func (it *pageIterator[T]) next() bool {
+	for it.err == nil && !it.done {
+		if it.index < len(it.items) {
+			it.item = it.items[it.index]
+			it.index++
+			return true
+		}
+		if it.maxPages > 0 && it.pages >= it.maxPages {
+			it.done = true
+			break
+		}
+		items, ok, err := it.fetch()
+		it.pages++
+		it.items, it.index, it.err = items, 0, err
+		it.done = !ok || len(items) == 0
+	}
+	return false
+}
+
+// collect gathers the remaining items of an iterator, stopping once maxItems items are collected if maxItems is
+// positive. On error it returns the items collected so far along with the error.
+func collect[T any](it *pageIterator[T], maxItems int) ([]T, error) {
+	var items []T
+	for (maxItems <= 0 || len(items) < maxItems) && it.next() {
+		items = append(items, it.item)
+	}
+	return items, it.err
+}
+
+// PhotoIterator walks every photo of a paginated photo listing, fetching the next page via NextPage when the
+// current one is exhausted. Pages are only requested as Next is called.
+// Use it like bufio.Scanner:
+//
+//	it := client.CuratedPhotosIter(ctx, &pexels.GetCuratedPhotoParams{})
+//	for it.Next() {
+//		photo := it.Photo()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// A PhotoIterator is not safe for concurrent use.
+type PhotoIterator struct {
+	it pageIterator[Photo]
+}
+
+// newPhotoIterator returns an iterator whose first page is fetched by first.
+func (c *Client) newPhotoIterator(ctx context.Context, first func() (*GetPhotoResponse, error)) *PhotoIterator {
+	return &PhotoIterator{newPageIterator(ctx, first, c.NextPhotoPage, func(r *GetPhotoResponse) []Photo { return r.Photos })}
+}
+
+// Next advances the iterator to the next photo, fetching a new page if needed.
+// It returns false when there are no more photos or a request failed; Err then tells which.
+func (it *PhotoIterator) Next() bool {
+	return it.it.next()
+}
+
+// Photo returns the photo the iterator is positioned at by the last call to Next.
+func (it *PhotoIterator) Photo() Photo {
+	return it.it.item
+}
+
+// Err returns the error that stopped the iteration, or nil if every page was walked.
+func (it *PhotoIterator) Err() error {
+	return it.it.err
+}
+
+// LimitPages stops the iterator after n pages have been fetched, so that a walk without an item limit still makes a
+// bounded number of requests. An n of zero or less removes the limit. It returns the iterator for chaining.
+func (it *PhotoIterator) LimitPages(n int) *PhotoIterator {
+	it.it.maxPages = n
+	return it
+}
+
+// CollectPhotos gathers the remaining photos of an iterator into a slice, stopping once maxItems photos are collected
+// if maxItems is positive, so no page beyond the one holding the last wanted photo is requested. Combine it with
+// LimitPages to also bound the number of requests. On error it returns the photos collected so far along with the error.
+func CollectPhotos(it *PhotoIterator, maxItems int) ([]Photo, error) {
+	return collect(&it.it, maxItems)
+}
+
+// PhotosIter returns an iterator over every photo matching a search, following the next page URLs.
+// It takes a context and GetPhotosParams as input and returns a PhotoIterator.
+func (c *Client) PhotosIter(ctx context.Context, params *GetPhotosParams) *PhotoIterator {
+	return c.newPhotoIterator(ctx, func() (*GetPhotoResponse, error) {
+		return c.GetPhotos(ctx, params)
+	})
+}
+
+// CuratedPhotosIter returns an iterator over the curated photo feed, following the next page URLs.
+// It takes a context and GetCuratedPhotoParams as input and returns a PhotoIterator.
+func (c *Client) CuratedPhotosIter(ctx context.Context, params *GetCuratedPhotoParams) *PhotoIterator {
+	return c.newPhotoIterator(ctx, func() (*GetPhotoResponse, error) {
+		return c.GetCurated(ctx, params)
+	})
+}
+
+// AllCuratedPhotos retrieves the curated photo feed from the Pexels API, walking pages until the feed ends.
+// It takes a context and the maximum number of photos to return as input and returns a list of photos and an error.
+// A maxItems of zero fetches the whole feed, so callers should normally set a limit. Pages are requested with
+// MaxPerPage results, or fewer when maxItems is smaller. On error, the photos fetched so far are returned with it.
+func (c *Client) AllCuratedPhotos(ctx context.Context, maxItems int) ([]Photo, error) {
+	perPage := MaxPerPage
+	if maxItems > 0 && maxItems < perPage {
+		perPage = maxItems
+	}
+	return CollectPhotos(c.CuratedPhotosIter(ctx, &GetCuratedPhotoParams{PerPage: Int(perPage)}), maxItems)
+}
+
+// VideoIterator walks every video of a paginated video listing, like PhotoIterator does for photos.
+// A VideoIterator is not safe for concurrent use.
+type VideoIterator struct {
+	it pageIterator[Video]
+}
+
+// Next advances the iterator to the next video, fetching a new page if needed.
+// It returns false when there are no more videos or a request failed; Err then tells which.
+func (it *VideoIterator) Next() bool {
+	return it.it.next()
+}
+
+// Video returns the video the iterator is positioned at by the last call to Next.
+func (it *VideoIterator) Video() Video {
+	return it.it.item
+}
+
+// Err returns the error that stopped the iteration, or nil if every page was walked.
+func (it *VideoIterator) Err() error {
+	return it.it.err
+}
+
+// LimitPages stops the iterator after n pages have been fetched. An n of zero or less removes the limit.
+// It returns the iterator for chaining.
+func (it *VideoIterator) LimitPages(n int) *VideoIterator {
+	it.it.maxPages = n
+	return it
+}
+
+// newVideoIterator returns an iterator whose first page is fetched by first.
+func (c *Client) newVideoIterator(ctx context.Context, first func() (*GetVideosResponse, error)) *VideoIterator {
+	return &VideoIterator{newPageIterator(ctx, first, c.NextVideoPage, func(r *GetVideosResponse) []Video { return r.Videos })}
+}
+
+// VideosIter returns an iterator over every video matching a search, following the next page URLs.
+// It takes a context and GetVideosParams as input and returns a VideoIterator.
+func (c *Client) VideosIter(ctx context.Context, params *GetVideosParams) *VideoIterator {
+	return c.newVideoIterator(ctx, func() (*GetVideosResponse, error) {
+		return c.GetVideos(ctx, params)
+	})
+}
+
+// PopularVideosIter returns an iterator over the popular videos, following the next page URLs.
+// It takes a context and GetPopularVideosParams as input and returns a VideoIterator.
+func (c *Client) PopularVideosIter(ctx context.Context, params *GetPopularVideosParams) *VideoIterator {
+	return c.newVideoIterator(ctx, func() (*GetVideosResponse, error) {
+		return c.GetPopularVideos(ctx, params)
+	})
+}
+
+// CollectVideos gathers the remaining videos of an iterator into a slice, stopping once maxItems videos are collected
+// if maxItems is positive. On error it returns the videos collected so far along with the error.
+func CollectVideos(it *VideoIterator, maxItems int) ([]Video, error) {
+	return collect(&it.it, maxItems)
+}
+
+// CollectionMediaIterator walks every media item of a collection, like PhotoIterator does for photos.
+// A CollectionMediaIterator is not safe for concurrent use.
+type CollectionMediaIterator struct {
+	it pageIterator[CollectionMedia]
+}
+
+// Next advances the iterator to the next media item, fetching a new page if needed.
+// It returns false when there are no more media or a request failed; Err then tells which.
+func (it *CollectionMediaIterator) Next() bool {
+	return it.it.next()
+}
+
+// Media returns the media item the iterator is positioned at by the last call to Next.
+func (it *CollectionMediaIterator) Media() CollectionMedia {
+	return it.it.item
+}
+
+// Err returns the error that stopped the iteration, or nil if every page was walked.
+func (it *CollectionMediaIterator) Err() error {
+	return it.it.err
+}
+
+// LimitPages stops the iterator after n pages have been fetched. An n of zero or less removes the limit.
+// It returns the iterator for chaining.
+func (it *CollectionMediaIterator) LimitPages(n int) *CollectionMediaIterator {
+	it.it.maxPages = n
+	return it
+}
+
+// CollectionMediaIter returns an iterator over the media of a collection, following the next page URLs.
+// It takes a context, an ID, and GetCollectionMediaParams as input and returns a CollectionMediaIterator.
+func (c *Client) CollectionMediaIter(ctx context.Context, id string, params *GetCollectionMediaParams) *CollectionMediaIterator {
+	first := func() (*GetCollectionMedia, error) {
+		return c.GetCollection(ctx, params, id)
+	}
+	return &CollectionMediaIterator{newPageIterator(ctx, first, c.NextCollectionMediaPage, func(r *GetCollectionMedia) []CollectionMedia { return r.Media })}
+}
+
+// CollectCollectionMedia gathers the remaining media of an iterator into a slice, stopping once maxItems media are
+// collected if maxItems is positive. On error it returns the media collected so far along with the error.
+func CollectCollectionMedia(it *CollectionMediaIterator, maxItems int) ([]CollectionMedia, error) {
+	return collect(&it.it, maxItems)
+}
@@ -0,0 +1,162 @@
+package pexels
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCuratedPhotosIter(t *testing.T) {
+	server := newPagedServer(7, 3)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	it := client.CuratedPhotosIter(context.Background(), &GetCuratedPhotoParams{PerPage: Int(3)})
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Photo().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("CuratedPhotosIter failed: %v", err)
+	}
+	if len(ids) != 7 || ids[0] != 1 || ids[6] != 7 {
+		t.Errorf("CuratedPhotosIter walked %v, want 1..7", ids)
+	}
+	if it.Next() {
+		t.Errorf("Next returned true after the last page")
+	}
+}
+
+func TestPhotosIterError(t *testing.T) {
+	server := newPagedServer(7, 3, 2)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	it := client.PhotosIter(context.Background(), &GetPhotosParams{Query: "nature", PerPage: Int(3)})
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if n != 3 || it.Err() == nil {
+		t.Errorf("PhotosIter walked %d photos with error %v, want 3 and an error", n, it.Err())
+	}
+}
+
+func TestAllCuratedPhotos(t *testing.T) {
+	server := newPagedServer(7, 3)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	tests := []struct {
+		maxItems int
+		want     int
+	}{
+		{0, 7},
+		{5, 5},
+		{20, 7},
+	}
+	for _, tt := range tests {
+		photos, err := client.AllCuratedPhotos(context.Background(), tt.maxItems)
+		if err != nil {
+			t.Fatalf("AllCuratedPhotos(%d) failed: %v", tt.maxItems, err)
+		}
+		if len(photos) != tt.want {
+			t.Errorf("AllCuratedPhotos(%d) returned %d photos, want %d", tt.maxItems, len(photos), tt.want)
+		}
+	}
+}
+
+func TestCollectPhotos(t *testing.T) {
+	server := newPagedServer(7, 3, 3)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	// The cap falls in the middle of the second page, so the failing third page is never requested
+	photos, err := CollectPhotos(client.PhotosIter(ctx, &GetPhotosParams{Query: "nature", PerPage: Int(3)}), 4)
+	if err != nil {
+		t.Fatalf("CollectPhotos failed: %v", err)
+	}
+	if len(photos) != 4 || photos[3].ID != 4 {
+		t.Errorf("CollectPhotos returned %v, want photos 1..4", photos)
+	}
+
+	// The failing third page returns the photos gathered so far with the error
+	photos, err = CollectPhotos(client.PhotosIter(ctx, &GetPhotosParams{Query: "nature", PerPage: Int(3)}), 0)
+	if err == nil || len(photos) != 6 {
+		t.Errorf("CollectPhotos = %d photos, %v, want 6 and an error", len(photos), err)
+	}
+}
+
+func TestCollectVideos(t *testing.T) {
+	server := newPagedServer(5, 2)
+	defer server.Close()
+	failing := newPagedServer(5, 2, 3)
+	defer failing.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	failingClient := NewClient("key", WithBaseURL(failing.URL+"/"))
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		it       *VideoIterator
+		maxItems int
+		want     int
+		wantErr  bool
+	}{
+		{"all", client.VideosIter(ctx, &GetVideosParams{Query: "ocean", PerPage: Int(2)}), 0, 5, false},
+		{"cap mid-page", client.VideosIter(ctx, &GetVideosParams{Query: "ocean", PerPage: Int(2)}), 3, 3, false},
+		{"cap above total", client.PopularVideosIter(ctx, &GetPopularVideosParams{PerPage: Int(2)}), 20, 5, false},
+		{"page cap", client.PopularVideosIter(ctx, &GetPopularVideosParams{PerPage: Int(2)}).LimitPages(2), 0, 4, false},
+		{"error", failingClient.VideosIter(ctx, &GetVideosParams{Query: "ocean", PerPage: Int(2)}), 0, 4, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			videos, err := CollectVideos(tt.it, tt.maxItems)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CollectVideos error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(videos) != tt.want {
+				t.Errorf("CollectVideos returned %d videos, want %d", len(videos), tt.want)
+			}
+			for i, v := range videos {
+				if v.ID != i+1 {
+					t.Errorf("videos[%d].ID = %d, want %d", i, v.ID, i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectLimitPages(t *testing.T) {
+	// The third page fails, so stopping after two pages proves it is never requested
+	server := newPagedServer(9, 3, 3)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	photos, err := CollectPhotos(client.CuratedPhotosIter(ctx, &GetCuratedPhotoParams{PerPage: Int(3)}).LimitPages(2), 0)
+	if err != nil || len(photos) != 6 {
+		t.Errorf("CollectPhotos with 2 pages = %d photos, %v, want 6", len(photos), err)
+	}
+
+	// The item cap still applies within the page cap
+	photos, err = CollectPhotos(client.CuratedPhotosIter(ctx, &GetCuratedPhotoParams{PerPage: Int(3)}).LimitPages(2), 4)
+	if err != nil || len(photos) != 4 {
+		t.Errorf("CollectPhotos with 2 pages and 4 items = %d photos, %v, want 4", len(photos), err)
+	}
+
+	// Collection media share the same paging
+	media, err := CollectCollectionMedia(client.CollectionMediaIter(ctx, "abc", &GetCollectionMediaParams{PerPage: Int(3)}), 5)
+	if err != nil || len(media) != 5 || media[4].ID != 5 {
+		t.Errorf("CollectCollectionMedia = %d media, %v, want 1..5", len(media), err)
+	}
+	media, err = CollectCollectionMedia(client.CollectionMediaIter(ctx, "abc", &GetCollectionMediaParams{PerPage: Int(3)}).LimitPages(1), 0)
+	if err != nil || len(media) != 3 {
+		t.Errorf("CollectCollectionMedia with 1 page = %d media, %v, want 3", len(media), err)
+	}
+	media, err = CollectCollectionMedia(client.CollectionMediaIter(ctx, "abc", &GetCollectionMediaParams{PerPage: Int(3)}), 0)
+	if err == nil || len(media) != 6 {
+		t.Errorf("CollectCollectionMedia = %d media, %v, want 6 and an error", len(media), err)
+	}
+}
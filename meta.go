@@ -0,0 +1,34 @@
+package pexels
+
+import (
+	"context"
+	"net/http"
+)
+
+// ResponseMeta describes how the response of a call was obtained. Attach one to a context with
+// ContextWithResponseMeta and pass that context to a client method to have it filled in.
+// Since it travels with the context of a single call, it stays accurate when the client is used concurrently.
+type ResponseMeta struct {
+	FromCache  bool // The response was served from the cache set with WithCache, without a request
+	StatusCode int  // HTTP status code of the final response, or 0 if served from the cache or none was received
+
+	// Response is the final HTTP response, for reading headers such as X-Request-Id or Cache-Control, or nil if
+	// served from the cache or none was received. Its body has already been read and closed; use the decoded
+	// result of the call instead. It is also set when the call failed with an APIError.
+	Response *http.Response
+}
+
+// responseMetaKey is the context key under which ContextWithResponseMeta stores a *ResponseMeta.
+type responseMetaKey struct{}
+
+// ContextWithResponseMeta returns a copy of ctx that makes client methods record metadata about their response in meta.
+// meta is overwritten by every call made with the returned context, so use one per call.
+func ContextWithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// responseMetaFromContext returns the *ResponseMeta attached to ctx, or nil if there is none.
+func responseMetaFromContext(ctx context.Context) *ResponseMeta {
+	meta, _ := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	return meta
+}
@@ -0,0 +1,356 @@
+package pexels
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created with NewClient.
+type Option func(*Client)
+
+// WithRetry makes the client retry requests that fail with 429, 500, 502, 503 or 504, or the status codes set with
+// WithRetryableStatus.
+// maxAttempts is the total number of attempts including the first one, and baseDelay is the
+// initial delay of the exponential backoff, capped at a minute, used when the server does not send a Retry-After header.
+// A Retry-After longer than a minute is not waited for and the error is returned at once. The X-Ratelimit-Reset
+// header marks the monthly quota rollover and never delays a retry.
+// Only GET and HEAD requests are retried. Others, such as POST, PUT or DELETE, are sent once, since a failed
+// response does not prove the server did not act on them and replaying could apply an edit twice.
+// Every current endpoint is a GET, so all of them are retried.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.baseDelay = baseDelay
+	}
+}
+
+// WithBackoff sets the strategy computing the delay between retries made with WithRetry, replacing the default
+// exponential backoff from the WithRetry base delay with equal jitter. Use FullJitter or EqualJitter with a maximum
+// delay, or a custom BackoffFunc. A Retry-After header sent by the server still takes precedence.
+func WithBackoff(strategy BackoffFunc) Option {
+	return func(c *Client) {
+		c.backoffFn = strategy
+	}
+}
+
+// WithClock sets the time source used to wait between retries and to compute and apply WithAutoThrottle delays,
+// by default the real time. It is meant for tests that check timing logic without sleeping.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithRetryableStatus replaces the status codes that WithRetry retries, by default 429, 500, 502, 503 and 504,
+// e.g. to also retry 408 Request Timeout or to stop retrying 500. Without WithRetry it has no effect, and with no
+// codes no status is retried. It panics if a code is outside the 400 to 599 range.
+func WithRetryableStatus(codes ...int) Option {
+	for _, code := range codes {
+		if code < 400 || code > 599 {
+			panic(fmt.Sprintf("pexels: WithRetryableStatus: status %d is not in the 400 to 599 range", code))
+		}
+	}
+	codes = append([]int{}, codes...)
+	return func(c *Client) {
+		c.retryStatus = codes
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+// Pexels asks API consumers to identify themselves; when unset DefaultUserAgent is used.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithBaseURL sets the base URL of the Pexels API, e.g. to point the client at a proxy or a test server.
+// The URL may use http or https, with or without a trailing slash; endpoint paths, and the API version for photo and
+// collection endpoints, are appended to it, so "http://pexels-proxy.internal" requests http://pexels-proxy.internal/v1/search.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithVersion sets the version of the Pexels API used by the client.
+func WithVersion(version string) Option {
+	return func(c *Client) {
+		c.Version = version
+	}
+}
+
+// WithHTTPClient sets the Doer used to send requests, replacing the default *http.Client.
+func WithHTTPClient(doer Doer) Option {
+	return func(c *Client) {
+		c.HTTPClient = doer
+	}
+}
+
+// WithQueryFallback controls what GetPhotos and GetVideos do with an empty query.
+// When enabled, a blank photo search returns curated photos and a blank video search returns popular videos.
+// When disabled, the default, an empty query is an error.
+func WithQueryFallback(enabled bool) Option {
+	return func(c *Client) {
+		c.queryFallback = enabled
+	}
+}
+
+// WithTimeout sets the timeout of the underlying *http.Client, which defaults to two minutes.
+// The timeout caps every request including downloads; WithTimeout(0) disables it so that callers
+// control deadlines entirely through the context passed to each method.
+// It has no effect when the client was given a Doer other than *http.Client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		if hc, ok := ownHTTPClient(c); ok {
+			hc.Timeout = timeout
+		}
+	}
+}
+
+// ownHTTPClient replaces the client's *http.Client with a shallow copy and returns it, so that options never write to
+// an *http.Client passed to WithHTTPClient, which the caller, or the whole process for http.DefaultClient, may share.
+// It returns false when the Doer is not an *http.Client.
+func ownHTTPClient(c *Client) (*http.Client, bool) {
+	hc, ok := c.HTTPClient.(*http.Client)
+	if !ok || hc == nil {
+		return nil, false
+	}
+	hcCopy := *hc
+	c.HTTPClient = &hcCopy
+	return &hcCopy, true
+}
+
+// WithTransport sets the http.RoundTripper used by the default HTTP client, e.g. to add tracing, metrics or custom TLS
+// settings. The transport is responsible for delegating to http.DefaultTransport or another base transport.
+// It composes with WithTimeout in either order, but has no effect when WithHTTPClient set a Doer other than *http.Client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		if hc, ok := ownHTTPClient(c); ok {
+			hc.Transport = rt
+		}
+	}
+}
+
+// WithMaxIdleConnsPerHost sets how many idle connections to the Pexels API the default transport keeps open for reuse.
+// Go's default is 2 (http.DefaultMaxIdleConnsPerHost), which causes connection churn when many goroutines share
+// a client; a value close to the expected concurrency is a good choice for heavy users.
+// Like the other transport tuning options, it has no effect when WithHTTPClient or WithTransport installed a
+// transport other than *http.Transport.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(c *Client) {
+		tuneTransport(c, func(t *http.Transport) {
+			t.MaxIdleConnsPerHost = n
+		})
+	}
+}
+
+// WithForceHTTP1 makes the default transport use HTTP/1.1 only, working around HTTP/2 stream stalls under high
+// concurrency. By default HTTP/2 is negotiated with the server when available.
+func WithForceHTTP1() Option {
+	return func(c *Client) {
+		tuneTransport(c, func(t *http.Transport) {
+			t.ForceAttemptHTTP2 = false
+			t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		})
+	}
+}
+
+// tuneTransport applies tune to a copy of the client's *http.Transport, starting from http.DefaultTransport when none
+// is set, and installs it on a copy of the *http.Client. Working on copies keeps clients sharing a transport or an
+// *http.Client, such as clones or a client passed to WithHTTPClient, unaffected.
+func tuneTransport(c *Client, tune func(*http.Transport)) {
+	hc, ok := c.HTTPClient.(*http.Client)
+	if !ok || hc == nil {
+		return
+	}
+	rt := hc.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return
+	}
+	t = t.Clone()
+	tune(t)
+	hc, _ = ownHTTPClient(c)
+	hc.Transport = t
+}
+
+// WithAPIKeyEnv sets the environment variable NewClientFromEnv reads the API key from.
+func WithAPIKeyEnv(name string) Option {
+	return func(c *Client) {
+		c.apiKeyEnv = name
+	}
+}
+
+// WithAuthHeader sends the API key under the named header instead of Authorization, for gateways and proxies
+// that strip or rewrite the Authorization header. An empty name keeps the default.
+func WithAuthHeader(name string) Option {
+	return func(c *Client) {
+		if name != "" {
+			c.authHeader = name
+		}
+	}
+}
+
+// WithRequestIDHeader sends the request ID stored in the request context by ContextWithRequestID under the named
+// header, e.g. X-Request-ID. Requests whose context carries no ID are sent without the header.
+func WithRequestIDHeader(name string) Option {
+	return func(c *Client) {
+		c.requestIDHdr = name
+	}
+}
+
+// WithCache caches GET responses in memory for ttl, keyed by the full request URL. The 1024 most recently used
+// responses are kept; use WithCacheBackend for a larger or shared cache.
+// A Cache-Control max-age sent by the server overrides ttl, and no-store or no-cache responses are not cached.
+func WithCache(ttl time.Duration) Option {
+	return WithCacheBackend(NewMemoryCache(), ttl)
+}
+
+// WithCacheBackend is like WithCache but stores responses in the given Cache, e.g. a Redis backed implementation.
+func WithCacheBackend(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// WithStrictPerPage makes list and search methods return an error when PerPage exceeds MaxPerPage.
+// By default such values are silently clamped to MaxPerPage.
+func WithStrictPerPage() Option {
+	return func(c *Client) {
+		c.strictPerPage = true
+	}
+}
+
+// WithoutLocaleValidation disables the local check of the Locale search parameter against SupportedLocales,
+// so that locales added to the Pexels API after this version of the library can still be used.
+func WithoutLocaleValidation() Option {
+	return func(c *Client) {
+		c.noLocaleCheck = true
+	}
+}
+
+// WithDefaultPerPage sets the number of results per page requested by every list and search method when PerPage is
+// left nil, replacing the per-endpoint defaults (5 for searches, curated photos and collections, 2 for popular
+// videos). Values above MaxPerPage are clamped to it, the maximum the API accepts; zero keeps the per-endpoint defaults.
+func WithDefaultPerPage(n int) Option {
+	return func(c *Client) {
+		if n > MaxPerPage {
+			n = MaxPerPage
+		}
+		c.defaultPerPage = n
+	}
+}
+
+// WithDefaultOrientation sets the orientation used by GetPhotos and GetVideos when the Orientation parameter is empty.
+// A non-empty Orientation in the parameters always wins. Like the parameters, o is lowercased first. It panics if o
+// is not a valid orientation, so that a misconfigured client fails at startup rather than on every search.
+func WithDefaultOrientation(o Orientation) Option {
+	o = Orientation(normalizeEnum(string(o)))
+	if err := validateEnum("Orientation", string(o), validOrientations); err != nil {
+		panic("pexels: WithDefaultOrientation: " + err.Error())
+	}
+	return func(c *Client) {
+		c.defaultOrient = o
+	}
+}
+
+// WithDefaultSize sets the minimum size used by GetPhotos and GetVideos when the Size parameter is empty.
+// A non-empty Size in the parameters always wins. Like the parameters, s is lowercased first. It panics if s is not
+// a valid size.
+func WithDefaultSize(s Size) Option {
+	s = Size(normalizeEnum(string(s)))
+	if err := validateEnum("Size", string(s), validSizes); err != nil {
+		panic("pexels: WithDefaultSize: " + err.Error())
+	}
+	return func(c *Client) {
+		c.defaultSize = s
+	}
+}
+
+// WithStrictDecoding makes responses containing fields that the response structs do not model fail to decode,
+// surfacing schema drift, e.g. in CI runs against recorded fixtures. By default unknown fields are ignored.
+// Video and CollectionMedia decode themselves to tolerate irregular tags and full_res values, so fields inside
+// them are not checked.
+func WithStrictDecoding() Option {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// WithAcceptLanguageFromLocale makes requests carrying a Locale parameter also send it as the Accept-Language header,
+// e.g. "pt-BR", for CDN responses that vary by language. Requests without a Locale send no Accept-Language header.
+func WithAcceptLanguageFromLocale() Option {
+	return func(c *Client) {
+		c.acceptLanguage = true
+	}
+}
+
+// WithMaxResponseBytes limits the size of API response bodies to n bytes, after decompression, to guard against
+// misbehaving servers or proxies. Larger responses fail with an error wrapping ErrResponseTooLarge. The default is
+// no limit; a cap of a few megabytes is ample, as the largest pages of results are well under one megabyte.
+// Downloads of photos and video files are not affected.
+func WithMaxResponseBytes(n int64) Option {
+	return func(c *Client) {
+		c.maxResponseBytes = n
+	}
+}
+
+// WithLogger sets a hook called after every request attempt, including retries, with details about the attempt.
+// It lets callers plug in any logging library; no work is done when no logger is set.
+func WithLogger(logger func(RequestInfo)) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithOnRequestComplete sets a hook called once per API call after its final attempt, with the request URL, the
+// status code of the last response (0 if none was received) and the total duration including retries.
+// It fires on errors too, but not for responses served from the cache. It suits latency histograms where
+// WithLogger would be too detailed.
+func WithOnRequestComplete(fn RequestCompleteFunc) Option {
+	return func(c *Client) {
+		c.onComplete = fn
+	}
+}
+
+// WithAutoThrottle makes the client space out requests based on the X-Ratelimit-Remaining and X-Ratelimit-Reset headers.
+// Once the remaining quota drops below 10% of the limit, each request waits so that the remaining requests are spread
+// evenly until the reset time, waiting at most a minute. The reset is the monthly quota rollover, so once the quota is
+// exhausted with the reset further away than that, requests fail with ErrRateLimited without being sent. Nothing is
+// throttled when the response has no X-Ratelimit-Limit header. The state is shared by all goroutines using the
+// client; ThrottleDelay exposes the current delay.
+func WithAutoThrottle() Option {
+	return func(c *Client) {
+		c.throttle = &throttle{}
+	}
+}
+
+// WithRateLimitCallback sets a hook called with the rate limit reported in the X-Ratelimit-* headers of every
+// response, including retried attempts; responses without these headers, such as cached ones, are skipped.
+// The hook runs synchronously on the goroutine making the request, before the method returns, so it should be quick
+// or hand the value off, e.g. to push Remaining to a store shared by several processes using the same key.
+// It may be called concurrently when the client is shared between goroutines.
+func WithRateLimitCallback(fn func(RateLimit)) Option {
+	return func(c *Client) {
+		c.onRateLimit = fn
+	}
+}
+
+// WithConditionalRequests makes the client remember the ETag and Last-Modified validators of GET responses and send
+// If-None-Match and If-Modified-Since on the next identical request. A 304 Not Modified response then returns the
+// previously decoded value instead of an error, saving bandwidth when polling slowly changing feeds; the WithLogger
+// hook reports such responses with StatusCode 304.
+// Combined with WithCache, requests are only revalidated once the cached entry has expired.
+// The validators and bodies of the 256 most recently used URLs are kept; older ones are evicted.
+func WithConditionalRequests() Option {
+	return func(c *Client) {
+		c.validators = newValidatorStore(maxValidators)
+	}
+}
@@ -0,0 +1,225 @@
+package pexels
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// MaxPerPage is the maximum number of results per page supported by the Pexels API.
+// Larger PerPage values are clamped to it, or rejected when the client was created with WithStrictPerPage.
+const MaxPerPage = 80
+
+// Int returns a pointer to v, for setting the optional Page and PerPage fields of request parameters:
+//
+//	params := &pexels.GetPhotosParams{Query: "nature", PerPage: pexels.Int(40)}
+func Int(v int) *int {
+	return &v
+}
+
+// applyPaging fills in the default page and per page values and enforces MaxPerPage.
+// A nil page becomes 1 and a nil perPage becomes the value set with WithDefaultPerPage, or defaultPerPage,
+// the endpoint's own default, when the option is not set. Explicit values below 1 are rejected rather than
+// replaced by a default. The pointed-to values are never modified, as callers may share them between requests.
+func (c *Client) applyPaging(page, perPage **int, defaultPerPage int) error {
+	switch {
+	case *page == nil:
+		*page = Int(1)
+	case **page < 1:
+		return fmt.Errorf("Page field must be at least 1, got %d.", **page)
+	}
+	switch {
+	case *perPage == nil:
+		*perPage = Int(defaultPerPage)
+		if c.defaultPerPage > 0 {
+			*perPage = Int(c.defaultPerPage)
+		}
+	case **perPage < 1:
+		return fmt.Errorf("PerPage field must be at least 1, got %d.", **perPage)
+	case **perPage > MaxPerPage:
+		if c.strictPerPage {
+			return fmt.Errorf("PerPage field must be at most %d, got %d.", MaxPerPage, **perPage)
+		}
+		*perPage = Int(MaxPerPage)
+	}
+	return nil
+}
+
+// pageNumber extracts the page query parameter from a next or previous page URL.
+// It returns false when the URL is empty or carries no valid page number.
+func pageNumber(link string) (int, bool) {
+	if link == "" {
+		return 0, false
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return 0, false
+	}
+	page, err := strconv.Atoi(u.Query().Get("page"))
+	if err != nil || page < 1 {
+		return 0, false
+	}
+	return page, true
+}
+
+// totalPages returns the number of pages needed to hold total results at perPage results per page.
+// It returns 0 when perPage is not positive.
+func totalPages(total, perPage int) int {
+	if perPage <= 0 || total <= 0 {
+		return 0
+	}
+	return (total + perPage - 1) / perPage
+}
+
+// TotalPages returns the number of pages of results, or 0 if PerPage is zero.
+func (r GetPhotoResponse) TotalPages() int {
+	return totalPages(r.TotalResults, r.PerPage)
+}
+
+// TotalPages returns the number of pages of results, or 0 if PerPage is zero.
+func (r GetVideosResponse) TotalPages() int {
+	return totalPages(r.TotalResults, r.PerPage)
+}
+
+// TotalPages returns the number of pages of results, or 0 if PerPage is zero.
+func (r GetCollectionsResponse) TotalPages() int {
+	return totalPages(r.TotalResults, r.PerPage)
+}
+
+// TotalPages returns the number of pages of results, or 0 if PerPage is zero.
+func (r GetCollectionMedia) TotalPages() int {
+	return totalPages(r.TotalResults, r.PerPage)
+}
+
+// IsEmpty reports whether the page holds no photos. Together with a nil error and TotalResults, it tells a search
+// that legitimately matched nothing apart from a failed call.
+func (r GetPhotoResponse) IsEmpty() bool {
+	return len(r.Photos) == 0
+}
+
+// IsEmpty reports whether the page holds no videos.
+func (r GetVideosResponse) IsEmpty() bool {
+	return len(r.Videos) == 0
+}
+
+// IsEmpty reports whether the page holds no collections.
+func (r GetCollectionsResponse) IsEmpty() bool {
+	return len(r.Collections) == 0
+}
+
+// IsEmpty reports whether the page holds no media.
+func (r GetCollectionMedia) IsEmpty() bool {
+	return len(r.Media) == 0
+}
+
+// IDs returns the IDs of the photos of the page in order, e.g. as cache keys. It is never nil.
+func (r GetPhotoResponse) IDs() []int {
+	ids := make([]int, 0, len(r.Photos))
+	for _, p := range r.Photos {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+// IDs returns the IDs of the videos of the page in order. It is never nil.
+func (r GetVideosResponse) IDs() []int {
+	ids := make([]int, 0, len(r.Videos))
+	for _, v := range r.Videos {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+// IDs returns the IDs of the collections of the page in order. It is never nil.
+func (r GetCollectionsResponse) IDs() []string {
+	ids := make([]string, 0, len(r.Collections))
+	for _, c := range r.Collections {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+// NextPageNumber returns the page number of the next page of results, or false if there is none.
+func (r GetPhotoResponse) NextPageNumber() (int, bool) {
+	return pageNumber(r.NextPage)
+}
+
+// PrevPageNumber returns the page number of the previous page of results, or false if there is none.
+func (r GetPhotoResponse) PrevPageNumber() (int, bool) {
+	return pageNumber(r.PrevPage)
+}
+
+// NextPageNumber returns the page number of the next page of results, or false if there is none.
+func (r GetVideosResponse) NextPageNumber() (int, bool) {
+	return pageNumber(r.NextPage)
+}
+
+// PrevPageNumber returns the page number of the previous page of results, or false if there is none.
+func (r GetVideosResponse) PrevPageNumber() (int, bool) {
+	return pageNumber(r.PrevPage)
+}
+
+// NextPageNumber returns the page number of the next page of results, or false if there is none.
+func (r GetCollectionsResponse) NextPageNumber() (int, bool) {
+	return pageNumber(r.NextPage)
+}
+
+// PrevPageNumber returns the page number of the previous page of results, or false if there is none.
+func (r GetCollectionsResponse) PrevPageNumber() (int, bool) {
+	return pageNumber(r.PrevPage)
+}
+
+// NextPageNumber returns the page number of the next page of results, or false if there is none.
+func (r GetCollectionMedia) NextPageNumber() (int, bool) {
+	return pageNumber(r.NextPage)
+}
+
+// PrevPageNumber returns the page number of the previous page of results, or false if there is none.
+func (r GetCollectionMedia) PrevPageNumber() (int, bool) {
+	return pageNumber(r.PrevPage)
+}
+
+// NextPhotoPage retrieves the page of photos that the NextPage URL of resp points to.
+// It takes a context and a GetPhotoResponse as input and returns the next GetPhotoResponse, whether there was a
+// next page, and an error. Following NextPage is more robust than computing page numbers, as the total may change
+// between requests. When resp has no next page, it returns nil and false without making a request.
+func (c *Client) NextPhotoPage(ctx context.Context, resp *GetPhotoResponse) (*GetPhotoResponse, bool, error) {
+	if resp == nil {
+		return nil, false, nil
+	}
+	return nextPage[GetPhotoResponse](ctx, c, resp.NextPage)
+}
+
+// NextVideoPage retrieves the page of videos that the NextPage URL of resp points to.
+// It takes a context and a GetVideosResponse as input and returns the next GetVideosResponse, whether there was a
+// next page, and an error. When resp has no next page, it returns nil and false without making a request.
+func (c *Client) NextVideoPage(ctx context.Context, resp *GetVideosResponse) (*GetVideosResponse, bool, error) {
+	if resp == nil {
+		return nil, false, nil
+	}
+	return nextPage[GetVideosResponse](ctx, c, resp.NextPage)
+}
+
+// NextCollectionMediaPage retrieves the page of collection media that the NextPage URL of resp points to.
+// It takes a context and a GetCollectionMedia as input and returns the next GetCollectionMedia, whether there was a
+// next page, and an error. When resp has no next page, it returns nil and false without making a request.
+func (c *Client) NextCollectionMediaPage(ctx context.Context, resp *GetCollectionMedia) (*GetCollectionMedia, bool, error) {
+	if resp == nil {
+		return nil, false, nil
+	}
+	return nextPage[GetCollectionMedia](ctx, c, resp.NextPage)
+}
+
+// nextPage retrieves the page that the next URL points to and decodes it into an R. It returns nil and false without
+// making a request when next is empty, and true with any error once a request was attempted.
+func nextPage[R any](ctx context.Context, c *Client, next string) (*R, bool, error) {
+	if next == "" {
+		return nil, false, nil
+	}
+	page := new(R)
+	if err := c.getURL(ctx, next, page); err != nil {
+		return nil, true, err
+	}
+	return page, true, nil
+}
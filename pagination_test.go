@@ -0,0 +1,334 @@
+package pexels
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPageNumbers(t *testing.T) {
+	tests := []struct {
+		link     string
+		wantPage int
+		wantOK   bool
+	}{
+		{"https://api.pexels.com/v1/search/?page=3&per_page=15&query=nature", 3, true},
+		{"https://api.pexels.com/v1/curated/?per_page=1&page=1", 1, true},
+		{"", 0, false},
+		{"https://api.pexels.com/v1/curated/?per_page=1", 0, false},
+		{"https://api.pexels.com/v1/curated/?page=abc", 0, false},
+	}
+	for _, tt := range tests {
+		photos := GetPhotoResponse{NextPage: tt.link, PrevPage: tt.link}
+		videos := GetVideosResponse{NextPage: tt.link, PrevPage: tt.link}
+		collections := GetCollectionsResponse{NextPage: tt.link, PrevPage: tt.link}
+		media := GetCollectionMedia{NextPage: tt.link, PrevPage: tt.link}
+		for name, f := range map[string]func() (int, bool){
+			"photos next":      photos.NextPageNumber,
+			"photos prev":      photos.PrevPageNumber,
+			"videos next":      videos.NextPageNumber,
+			"videos prev":      videos.PrevPageNumber,
+			"collections next": collections.NextPageNumber,
+			"collections prev": collections.PrevPageNumber,
+			"media next":       media.NextPageNumber,
+			"media prev":       media.PrevPageNumber,
+		} {
+			if page, ok := f(); page != tt.wantPage || ok != tt.wantOK {
+				t.Errorf("%s(%q) = %d, %v, want %d, %v", name, tt.link, page, ok, tt.wantPage, tt.wantOK)
+			}
+		}
+	}
+}
+
+func TestPerPageClamp(t *testing.T) {
+	tests := []struct {
+		perPage    int
+		want       string
+		wantStrict bool // whether strict mode rejects the value
+	}{
+		{1, "1", false},
+		{80, "80", false},
+		{81, "80", true},
+	}
+	for _, tt := range tests {
+		var req *http.Request
+		client := newStubClient(`{}`, &req)
+		if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{PerPage: Int(tt.perPage)}); err != nil {
+			t.Fatalf("GetCurated(%d) failed: %v", tt.perPage, err)
+		}
+		if got := req.URL.Query().Get("per_page"); got != tt.want {
+			t.Errorf("GetCurated(%d) per_page = %q, want %q", tt.perPage, got, tt.want)
+		}
+
+		req = nil
+		strict := newStubClient(`{}`, &req, WithStrictPerPage())
+		_, err := strict.GetVideos(context.Background(), &GetVideosParams{Query: "sea", PerPage: Int(tt.perPage)})
+		if (err != nil) != tt.wantStrict {
+			t.Errorf("strict GetVideos(%d) error = %v, wantErr %v", tt.perPage, err, tt.wantStrict)
+		}
+		if tt.wantStrict && req != nil {
+			t.Errorf("strict GetVideos(%d) sent a request", tt.perPage)
+		}
+	}
+}
+
+func TestPagingNilVersusExplicit(t *testing.T) {
+	ctx := context.Background()
+
+	// Nil fields take the defaults
+	var req *http.Request
+	client := newStubClient(`{}`, &req)
+	if _, err := client.GetPhotos(ctx, &GetPhotosParams{Query: "nature"}); err != nil {
+		t.Fatalf("GetPhotos failed: %v", err)
+	}
+	if q := req.URL.Query(); q.Get("page") != "1" || q.Get("per_page") != "5" {
+		t.Errorf("nil paging sent page=%s per_page=%s, want 1 and 5", q.Get("page"), q.Get("per_page"))
+	}
+
+	// Explicit values are sent as is, large ones clamped without touching the caller's value
+	perPage := 200
+	params := &GetCollectionMediaParams{Page: Int(3), PerPage: &perPage}
+	if _, err := client.GetCollection(ctx, params, "abc"); err != nil {
+		t.Fatalf("GetCollection failed: %v", err)
+	}
+	if q := req.URL.Query(); q.Get("page") != "3" || q.Get("per_page") != "80" {
+		t.Errorf("explicit paging sent page=%s per_page=%s, want 3 and 80", q.Get("page"), q.Get("per_page"))
+	}
+	if perPage != 200 {
+		t.Errorf("clamping changed the caller's PerPage to %d", perPage)
+	}
+
+	// Explicit zeros are rejected instead of silently becoming the default
+	for name, call := range map[string]func() error{
+		"PerPage": func() error {
+			_, err := client.GetCurated(ctx, &GetCuratedPhotoParams{PerPage: Int(0)})
+			return err
+		},
+		"Page": func() error {
+			_, err := client.GetPopularVideos(ctx, &GetPopularVideosParams{Page: Int(0)})
+			return err
+		},
+	} {
+		req = nil
+		if err := call(); err == nil || !strings.Contains(err.Error(), name+" field") {
+			t.Errorf("explicit zero %s error = %v, want a %s field error", name, err, name)
+		}
+		if req != nil {
+			t.Errorf("explicit zero %s sent a request", name)
+		}
+	}
+}
+
+func TestTotalPages(t *testing.T) {
+	tests := []struct {
+		name           string
+		total, perPage int
+		want           int
+	}{
+		{"exact multiple", 30, 15, 2},
+		{"remainder", 31, 15, 3},
+		{"fewer than a page", 4, 15, 1},
+		{"no results", 0, 15, 0},
+		{"zero per page", 31, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, got := range map[string]int{
+				"photos":      GetPhotoResponse{TotalResults: tt.total, PerPage: tt.perPage}.TotalPages(),
+				"videos":      GetVideosResponse{TotalResults: tt.total, PerPage: tt.perPage}.TotalPages(),
+				"collections": GetCollectionsResponse{TotalResults: tt.total, PerPage: tt.perPage}.TotalPages(),
+				"media":       GetCollectionMedia{TotalResults: tt.total, PerPage: tt.perPage}.TotalPages(),
+			} {
+				if got != tt.want {
+					t.Errorf("%s TotalPages() = %d, want %d", name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	photoServer := newPagedServer(5, 3)
+	defer photoServer.Close()
+	collectionServer := newPagedServer(5, 3)
+	defer collectionServer.Close()
+	ctx := context.Background()
+
+	client := NewClient("key", WithBaseURL(photoServer.URL+"/"))
+	photos, err := client.GetCurated(ctx, &GetCuratedPhotoParams{PerPage: Int(3)})
+	if err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	photos, ok, err := client.NextPhotoPage(ctx, photos)
+	if err != nil || !ok || photos.Page != 2 || len(photos.Photos) != 2 {
+		t.Fatalf("NextPhotoPage = %+v, %v, %v, want page 2 with 2 photos", photos, ok, err)
+	}
+	if next, ok, err := client.NextPhotoPage(ctx, photos); next != nil || ok || err != nil {
+		t.Errorf("NextPhotoPage on the last page = %+v, %v, %v, want nil, false, nil", next, ok, err)
+	}
+
+	client = NewClient("key", WithBaseURL(collectionServer.URL+"/"))
+	media, err := client.GetCollection(ctx, &GetCollectionMediaParams{PerPage: Int(3)}, "abc")
+	if err != nil {
+		t.Fatalf("GetCollection failed: %v", err)
+	}
+	media, ok, err = client.NextCollectionMediaPage(ctx, media)
+	if err != nil || !ok || media.Page != 2 || len(media.Media) != 2 {
+		t.Fatalf("NextCollectionMediaPage = %+v, %v, %v, want page 2 with 2 media", media, ok, err)
+	}
+	if next, ok, err := client.NextCollectionMediaPage(ctx, media); next != nil || ok || err != nil {
+		t.Errorf("NextCollectionMediaPage on the last page = %+v, %v, %v, want nil, false, nil", next, ok, err)
+	}
+
+	if next, ok, err := client.NextVideoPage(ctx, &GetVideosResponse{}); next != nil || ok || err != nil {
+		t.Errorf("NextVideoPage without a next page = %+v, %v, %v, want nil, false, nil", next, ok, err)
+	}
+}
+
+func TestDefaultPerPage(t *testing.T) {
+	ctx := context.Background()
+	calls := map[string]func(c *Client) error{
+		"GetPhotos": func(c *Client) error {
+			_, err := c.GetPhotos(ctx, &GetPhotosParams{Query: "nature"})
+			return err
+		},
+		"GetPopularVideos": func(c *Client) error {
+			_, err := c.GetPopularVideos(ctx, &GetPopularVideosParams{})
+			return err
+		},
+		"GetFeaturedCollections": func(c *Client) error {
+			_, err := c.GetFeaturedCollections(ctx, &GetFeaturedCollectionParams{})
+			return err
+		},
+	}
+	tests := []struct {
+		name string
+		opts []Option
+		want map[string]string
+	}{
+		{"endpoint defaults", nil, map[string]string{"GetPhotos": "5", "GetPopularVideos": "2", "GetFeaturedCollections": "5"}},
+		{"uniform default", []Option{WithDefaultPerPage(20)}, map[string]string{"GetPhotos": "20", "GetPopularVideos": "20", "GetFeaturedCollections": "20"}},
+		{"clamped default", []Option{WithDefaultPerPage(500)}, map[string]string{"GetPhotos": "80", "GetPopularVideos": "80", "GetFeaturedCollections": "80"}},
+	}
+	for _, tt := range tests {
+		for name, call := range calls {
+			var req *http.Request
+			if err := call(newStubClient(`{}`, &req, tt.opts...)); err != nil {
+				t.Fatalf("%s failed: %v", name, err)
+			}
+			if got := req.URL.Query().Get("per_page"); got != tt.want[name] {
+				t.Errorf("%s: %s per_page = %s, want %s", tt.name, name, got, tt.want[name])
+			}
+		}
+	}
+
+	// An explicit PerPage still wins
+	var req *http.Request
+	client := newStubClient(`{}`, &req, WithDefaultPerPage(20))
+	if _, err := client.GetCurated(ctx, &GetCuratedPhotoParams{PerPage: Int(7)}); err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	if got := req.URL.Query().Get("per_page"); got != "7" {
+		t.Errorf("per_page = %s, want 7", got)
+	}
+}
+
+func TestEmptyResults(t *testing.T) {
+	ctx := context.Background()
+	var req *http.Request
+
+	photos, err := newStubClient(`{"page":1,"per_page":15,"photos":[],"total_results":0}`, &req).GetPhotos(ctx, &GetPhotosParams{Query: "xyzzy"})
+	if err != nil {
+		t.Fatalf("GetPhotos failed: %v", err)
+	}
+	if !photos.IsEmpty() || photos.TotalResults != 0 || photos.Page != 1 || photos.PerPage != 15 || photos.TotalPages() != 0 {
+		t.Errorf("zero-result search = %+v", photos)
+	}
+	if photos.Photos == nil {
+		t.Errorf("zero-result search left Photos nil, want an empty slice")
+	}
+
+	videos, err := newStubClient(`{"page":1,"per_page":15,"videos":[],"total_results":0}`, &req).GetVideos(ctx, &GetVideosParams{Query: "xyzzy"})
+	if err != nil || !videos.IsEmpty() || videos.TotalResults != 0 || videos.PerPage != 15 {
+		t.Errorf("zero-result video search = %+v, %v", videos, err)
+	}
+
+	if !(GetCollectionsResponse{}).IsEmpty() || (GetCollectionMedia{Media: []CollectionMedia{{}}}).IsEmpty() {
+		t.Errorf("IsEmpty does not follow the number of items")
+	}
+	if (GetPhotoResponse{TotalResults: 10, Photos: []Photo{{ID: 1}}}).IsEmpty() {
+		t.Errorf("IsEmpty reported a page holding a photo as empty")
+	}
+}
+
+func TestResponseIDs(t *testing.T) {
+	photos := GetPhotoResponse{Photos: []Photo{{ID: 3}, {ID: 1}, {ID: 2}}}
+	if got := photos.IDs(); fmt.Sprint(got) != "[3 1 2]" {
+		t.Errorf("GetPhotoResponse.IDs() = %v, want [3 1 2]", got)
+	}
+	videos := GetVideosResponse{Videos: []Video{{ID: 10}, {ID: 11}}}
+	if got := videos.IDs(); fmt.Sprint(got) != "[10 11]" {
+		t.Errorf("GetVideosResponse.IDs() = %v, want [10 11]", got)
+	}
+	collections := GetCollectionsResponse{Collections: []Collection{{ID: "abc"}, {ID: "xyz"}}}
+	if got := collections.IDs(); fmt.Sprint(got) != "[abc xyz]" {
+		t.Errorf("GetCollectionsResponse.IDs() = %v, want [abc xyz]", got)
+	}
+
+	// Empty pages give empty, non-nil slices
+	if got := (GetPhotoResponse{}).IDs(); got == nil || len(got) != 0 {
+		t.Errorf("GetPhotoResponse.IDs() = %#v, want an empty slice", got)
+	}
+	if got := (GetVideosResponse{Videos: []Video{}}).IDs(); got == nil || len(got) != 0 {
+		t.Errorf("GetVideosResponse.IDs() = %#v, want an empty slice", got)
+	}
+	if got := (GetCollectionsResponse{}).IDs(); got == nil || len(got) != 0 {
+		t.Errorf("GetCollectionsResponse.IDs() = %#v, want an empty slice", got)
+	}
+}
+
+func TestNextPageStaysOnBaseURL(t *testing.T) {
+	var foreignRequests int32
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&foreignRequests, 1)
+		w.Write([]byte(`{}`))
+	}))
+	defer foreign.Close()
+	var gotKey, gotPage string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey, gotPage = r.Header.Get("Authorization"), r.URL.Query().Get("page")
+		w.Write([]byte(`{"page":2}`))
+	}))
+	defer api.Close()
+	ctx := context.Background()
+
+	// A next page on another host is requested from BaseURL, and the key never reaches that host
+	client := NewClient("secret-key", WithBaseURL(api.URL+"/"))
+	for _, next := range []string{foreign.URL + "/v1/curated?page=2", "https://api.pexels.com/v1/curated?page=2", "/v1/curated?page=2"} {
+		gotKey, gotPage = "", ""
+		resp, _, err := client.NextPhotoPage(ctx, &GetPhotoResponse{NextPage: next})
+		if err != nil || resp.Page != 2 {
+			t.Fatalf("NextPhotoPage(%q) = %+v, %v", next, resp, err)
+		}
+		if gotKey != "secret-key" || gotPage != "2" {
+			t.Errorf("NextPhotoPage(%q) reached BaseURL with key %q and page %q", next, gotKey, gotPage)
+		}
+	}
+	if foreignRequests != 0 {
+		t.Errorf("the foreign host received %d requests", foreignRequests)
+	}
+
+	// A proxy set with WithBaseURL also serves the pages linked by the live API
+	var req *http.Request
+	client = newStubClient(`{}`, &req, WithBaseURL("https://proxy.example.com/pexels/"))
+	if _, _, err := client.NextVideoPage(ctx, &GetVideosResponse{NextPage: "https://api.pexels.com/videos/search?page=2&query=sea"}); err != nil {
+		t.Fatalf("NextVideoPage failed: %v", err)
+	}
+	if got, want := req.URL.String(), "https://proxy.example.com/pexels/videos/search?page=2&query=sea"; got != want {
+		t.Errorf("NextVideoPage URL = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package pexels
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ParsePhotoID extracts the photo ID from a Pexels photo page URL such as
+// https://www.pexels.com/photo/green-leaves-2014422/. It takes the URL as input and returns the ID,
+// ready to pass to GetPhoto, or an error if the URL is not a Pexels photo page.
+func ParsePhotoID(rawURL string) (string, error) {
+	return parseMediaID(rawURL, "photo")
+}
+
+// ParseVideoID extracts the video ID from a Pexels video page URL such as
+// https://www.pexels.com/video/waves-crashing-857251/. It takes the URL as input and returns the ID,
+// ready to pass to GetVideo, or an error if the URL is not a Pexels video page.
+func ParseVideoID(rawURL string) (string, error) {
+	return parseMediaID(rawURL, "video")
+}
+
+// PhotoPageURL returns the URL of the pexels.com page of the photo with the given ID, such as
+// https://www.pexels.com/photo/2014422/, without a network call. Pexels redirects it to the page with the photo's slug.
+func PhotoPageURL(id int) string {
+	return mediaPageURL("photo", id)
+}
+
+// VideoPageURL returns the URL of the pexels.com page of the video with the given ID, such as
+// https://www.pexels.com/video/857251/, without a network call.
+func VideoPageURL(id int) string {
+	return mediaPageURL("video", id)
+}
+
+// mediaPageURL returns the pexels.com page URL of the media of the given kind and ID.
+func mediaPageURL(kind string, id int) string {
+	return fmt.Sprintf("https://www.pexels.com/%s/%d/", kind, id)
+}
+
+// parseMediaID returns the trailing numeric ID of the path segment following kind in a pexels.com URL.
+// Query strings, fragments, trailing slashes and a leading locale segment such as /de-de/ are ignored.
+func parseMediaID(rawURL, kind string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("pexels: parsing %s URL: %w", kind, err)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host != "pexels.com" && !strings.HasSuffix(host, ".pexels.com") {
+		return "", fmt.Errorf("pexels: %q is not a pexels.com URL", rawURL)
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] != kind {
+			continue
+		}
+		slug := segments[i+1]
+		id := slug[strings.LastIndex(slug, "-")+1:]
+		if id != "" && isDigits(id) {
+			return id, nil
+		}
+		break
+	}
+	return "", fmt.Errorf("pexels: %q is not a Pexels %s URL", rawURL, kind)
+}
+
+// isDigits reports whether s consists only of ASCII digits.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
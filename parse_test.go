@@ -0,0 +1,57 @@
+package pexels
+
+import "testing"
+
+func TestParsePhotoID(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{"https://www.pexels.com/photo/green-leaves-2014422/", "2014422", false},
+		{"https://www.pexels.com/photo/green-leaves-2014422", "2014422", false},
+		{"https://www.pexels.com/photo/green-leaves-2014422/?utm_source=x#top", "2014422", false},
+		{"https://www.pexels.com/photo/2014422/", "2014422", false},
+		{"https://www.pexels.com/de-de/foto/gruene-blaetter-2014422/", "", true},
+		{"https://www.pexels.com/de-de/photo/green-leaves-2014422/", "2014422", false},
+		{"https://pexels.com/photo/green-leaves-2014422/", "2014422", false},
+		{"https://www.pexels.com/video/waves-857251/", "", true},
+		{"https://www.pexels.com/photo/green-leaves/", "", true},
+		{"https://www.pexels.com/photo/", "", true},
+		{"https://example.com/photo/green-leaves-2014422/", "", true},
+		{"not a url", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePhotoID(tt.url)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParsePhotoID(%q) = %q, %v; want %q, error %v", tt.url, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseVideoID(t *testing.T) {
+	got, err := ParseVideoID("https://www.pexels.com/video/waves-crashing-857251/")
+	if err != nil || got != "857251" {
+		t.Errorf("ParseVideoID = %q, %v; want 857251", got, err)
+	}
+	if _, err := ParseVideoID("https://www.pexels.com/photo/green-leaves-2014422/"); err == nil {
+		t.Errorf("ParseVideoID accepted a photo URL")
+	}
+}
+
+func TestMediaPageURL(t *testing.T) {
+	if got := PhotoPageURL(2014422); got != "https://www.pexels.com/photo/2014422/" {
+		t.Errorf("PhotoPageURL = %q", got)
+	}
+	if got := VideoPageURL(857251); got != "https://www.pexels.com/video/857251/" {
+		t.Errorf("VideoPageURL = %q", got)
+	}
+
+	// The page URLs parse back to the same IDs
+	if id, err := ParsePhotoID(PhotoPageURL(2014422)); err != nil || id != "2014422" {
+		t.Errorf("ParsePhotoID(PhotoPageURL) = %q, %v", id, err)
+	}
+	if id, err := ParseVideoID(VideoPageURL(857251)); err != nil || id != "857251" {
+		t.Errorf("ParseVideoID(VideoPageURL) = %q, %v", id, err)
+	}
+}
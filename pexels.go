@@ -1,28 +1,93 @@
 package pexels
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 )
 
-// BaseURL is the base URL for the Pexels API.
-var BaseURL = "https://api.pexels.com/"
+// DefaultBaseURL is the base URL for the Pexels API used by NewClient unless overridden with WithBaseURL.
+const DefaultBaseURL = "https://api.pexels.com/"
 
-// Version is the version of the Pexels API being used.
-var Version = "v1"
+// DefaultVersion is the version of the Pexels API used by NewClient unless overridden with WithVersion.
+const DefaultVersion = "v1"
+
+// libraryVersion is the version of this client library, reported in the default User-Agent header.
+const libraryVersion = "0.1.0"
+
+// DefaultUserAgent is the User-Agent header sent with every request unless overridden with WithUserAgent.
+const DefaultUserAgent = "pexels-go/" + libraryVersion
+
+// Doer sends HTTP requests and returns HTTP responses.
+// It is satisfied by *http.Client and lets tests substitute a fake that returns canned responses.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DefaultAPIKeyEnv is the environment variable NewClientFromEnv reads the API key from unless overridden with WithAPIKeyEnv.
+const DefaultAPIKeyEnv = "PEXELS_API_KEY"
+
+// DefaultAuthHeader is the request header carrying the API key unless overridden with WithAuthHeader.
+const DefaultAuthHeader = "Authorization"
 
 // Client represents a client for the Pexels API.
 type Client struct {
-	BaseURL    string       // The base URL for the Pexels API
-	ApiKey     string       // The API key for accessing the Pexels API
-	HTTPClient *http.Client // The HTTP client for making requests
-	Version    string       // The version of the Pexels API being used
+	BaseURL    string // The base URL for the Pexels API
+	ApiKey     string // The API key for accessing the Pexels API
+	HTTPClient Doer   // The HTTP client for making requests
+	Version    string // The version of the Pexels API being used
+
+	maxAttempts      int                 // Maximum number of attempts per request, see WithRetry
+	baseDelay        time.Duration       // Initial backoff delay between attempts, see WithRetry
+	userAgent        string              // User-Agent header sent with every request, see WithUserAgent
+	queryFallback    bool                // Route blank search queries to the curated/popular endpoints, see WithQueryFallback
+	apiKeyEnv        string              // Environment variable read by NewClientFromEnv, see WithAPIKeyEnv
+	cache            Cache               // Cache for GET responses, see WithCache
+	cacheTTL         time.Duration       // Default lifetime of cached responses, see WithCache
+	strictPerPage    bool                // Reject PerPage values above MaxPerPage instead of clamping, see WithStrictPerPage
+	defaultPerPage   int                 // PerPage used when a request leaves it nil, see WithDefaultPerPage
+	logger           func(RequestInfo)   // Hook called after every request attempt, see WithLogger
+	throttle         *throttle           // Rate limit state used to space requests, see WithAutoThrottle
+	validators       *validatorStore     // ETag and Last-Modified validators of previous responses, see WithConditionalRequests
+	stats            *requestCounters    // Request, retry and rate limit counters, see Stats
+	authHeader       string              // Header carrying the API key, see WithAuthHeader
+	noLocaleCheck    bool                // Send any Locale value without checking it, see WithoutLocaleValidation
+	onComplete       RequestCompleteFunc // Hook called once per API call, see WithOnRequestComplete
+	requestIDHdr     string              // Header carrying the context's request ID, see WithRequestIDHeader
+	strictDecoding   bool                // Reject response fields the structs do not model, see WithStrictDecoding
+	onRateLimit      func(RateLimit)     // Hook called with the rate limit headers of every response, see WithRateLimitCallback
+	acceptLanguage   bool                // Send the locale query parameter as Accept-Language, see WithAcceptLanguageFromLocale
+	maxResponseBytes int64               // Maximum size of a response body, 0 for no limit, see WithMaxResponseBytes
+	defaultOrient    Orientation         // Orientation used by searches that leave it empty, see WithDefaultOrientation
+	defaultSize      Size                // Size used by searches that leave it empty, see WithDefaultSize
+	retryStatus      []int               // Status codes retried by WithRetry, nil for the defaults, see WithRetryableStatus
+	backoffFn        BackoffFunc         // Delay between retries, nil for the default, see WithBackoff
+	clock            Clock               // Time source for retries and throttling, see WithClock
+}
+
+// RequestCompleteFunc is the hook set with WithOnRequestComplete. It receives the request URL, the status code of
+// the last response or 0 if none was received, and the total duration of the call.
+type RequestCompleteFunc func(url string, status int, dur time.Duration)
+
+// RequestInfo describes a single attempt of a request to the Pexels API, as passed to the WithLogger hook.
+type RequestInfo struct {
+	Method             string        // HTTP method of the request
+	URL                string        // Full URL of the request
+	StatusCode         int           // HTTP status code of the response, or 0 if no response was received
+	Latency            time.Duration // Time taken by the attempt
+	Attempt            int           // Attempt number, starting at 1 and increasing with retries
+	RateLimitRemaining int           // Remaining requests reported by the API, or -1 if unknown
+	Err                error         // Error of the attempt, if any
 }
 
 // User represents a user in the Pexels API.
@@ -32,54 +97,549 @@ type User struct {
 	URL  string `json:"url"`  // URL to the user's profile
 }
 
+// Orientation is the orientation of photos or videos to search for.
+type Orientation string
+
+// Supported orientations for photo and video searches.
+const (
+	OrientationLandscape Orientation = "landscape"
+	OrientationPortrait  Orientation = "portrait"
+	OrientationSquare    Orientation = "square"
+)
+
+// Size is the minimum size of photos or videos to search for.
+type Size string
+
+// Supported sizes for photo and video searches.
+const (
+	SizeLarge  Size = "large"
+	SizeMedium Size = "medium"
+	SizeSmall  Size = "small"
+)
+
+// SortOrder is the order of the media returned by GetCollection.
+type SortOrder string
+
+// Supported sort orders for collection media.
+const (
+	SortPopular SortOrder = "popular"
+	SortLatest  SortOrder = "latest"
+)
+
 // NewClient creates a new Pexels API client.
-// It takes an API key as input and returns a new Client instance.
-func NewClient(apiKey string) *Client {
-	return &Client{
-		BaseURL: BaseURL,
+// It takes an API key and optional functional options as input and returns a new Client instance.
+func NewClient(apiKey string, opts ...Option) *Client {
+	c := &Client{
+		BaseURL: DefaultBaseURL,
 		ApiKey:  apiKey,
 		HTTPClient: &http.Client{
 			Timeout: time.Minute * 2,
 		},
-		Version: Version,
+		Version:     DefaultVersion,
+		maxAttempts: 1,
+		userAgent:   DefaultUserAgent,
+		apiKeyEnv:   DefaultAPIKeyEnv,
+		stats:       &requestCounters{},
+		clock:       realClock{},
+		authHeader:  DefaultAuthHeader,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Clone returns a copy of the client with the given options applied on top of its settings, e.g. to use a different
+// timeout or logger for a subtask without re-creating the client. The original client is left unchanged.
+// The copy intentionally shares the HTTP transport, and thus the connection pool, as well as the response cache,
+// rate limit state and request counters. When HTTPClient is an *http.Client it is copied, so options such as
+// WithTimeout only affect the clone; any other Doer is shared as is.
+func (c *Client) Clone(opts ...Option) *Client {
+	clone := *c
+	if hc, ok := c.HTTPClient.(*http.Client); ok {
+		hcCopy := *hc
+		clone.HTTPClient = &hcCopy
+	}
+	for _, opt := range opts {
+		opt(&clone)
+	}
+	return &clone
+}
+
+// NewClientFromEnv creates a new Pexels API client using the API key stored in an environment variable.
+// It takes optional functional options as input and returns a new Client instance and an error.
+// The key is read from PEXELS_API_KEY, or the variable set with WithAPIKeyEnv; an error is returned when it is unset or empty.
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	c := NewClient("", opts...)
+	c.ApiKey = os.Getenv(c.apiKeyEnv)
+	if c.ApiKey == "" {
+		return nil, fmt.Errorf("pexels: environment variable %s is not set", c.apiKeyEnv)
+	}
+	return c, nil
+}
+
+// CheckAPIKey reports whether the client's API key looks usable without contacting the API.
+// It returns an error wrapping ErrInvalidAPIKey when the key is empty or contains whitespace.
+func (c *Client) CheckAPIKey() error {
+	if c.ApiKey == "" {
+		return fmt.Errorf("%w: key is empty", ErrInvalidAPIKey)
+	}
+	if strings.ContainsAny(c.ApiKey, " \t\r\n") {
+		return fmt.Errorf("%w: key contains whitespace", ErrInvalidAPIKey)
+	}
+	return nil
+}
+
+// Validate verifies the API key by making a cheap authenticated request for a single curated photo.
+// It takes a context as input and returns an error if the key is malformed or rejected by the API.
+// The probe consumes one request from the rate limit, so it is best called once at startup. It is equivalent to Ping.
+func (c *Client) Validate(ctx context.Context) error {
+	return c.Ping(ctx)
+}
+
+// Ping checks connectivity to the Pexels API and the validity of the API key, e.g. for readiness probes.
+// It takes a context as input and returns nil on success or an error otherwise.
+// It makes the smallest authenticated call, one curated photo with per_page=1, bypassing the cache so that the API is
+// actually reached; each call consumes one request from the rate limit. A malformed key or a 401 response returns an
+// error matching ErrInvalidAPIKey; the latter also matches ErrUnauthorized.
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.CheckAPIKey(); err != nil {
+		return err
+	}
+	probe := c.Clone()
+	probe.cache = nil
+	_, err := probe.GetCurated(ctx, &GetCuratedPhotoParams{Page: Int(1), PerPage: Int(1)})
+	return err
+}
+
+// newRequest creates an HTTP request to the Pexels API with the standard headers set.
+// It takes a context, an HTTP method, and a URL as input and returns the request and an error.
+// It fails with ErrInvalidAPIKey, without any network call, when CheckAPIKey rejects the key.
+// The request has no body, so no Content-Type header is set; an endpoint sending a JSON body must set it itself.
+func (c *Client) newRequest(ctx context.Context, method, url string) (*http.Request, error) {
+	if err := c.CheckAPIKey(); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(c.authHeaderName(), c.ApiKey)
+	req.Header.Set("User-Agent", c.userAgentValue())
+	if c.acceptLanguage {
+		if locale := req.URL.Query().Get("locale"); locale != "" {
+			req.Header.Set("Accept-Language", locale)
+		}
+	}
+	if c.requestIDHdr != "" {
+		if id, ok := RequestIDFromContext(ctx); ok {
+			req.Header.Set(c.requestIDHdr, id)
+		}
+	}
+	for name, values := range headersFromContext(ctx) {
+		req.Header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+	}
+	return req, nil
+}
+
+// authHeaderName returns the header carrying the API key, DefaultAuthHeader unless WithAuthHeader set another one.
+// The fallback keeps a Client built as a struct literal rather than with NewClient working.
+func (c *Client) authHeaderName() string {
+	if c.authHeader == "" {
+		return DefaultAuthHeader
+	}
+	return c.authHeader
+}
+
+// userAgentValue returns the User-Agent header value, DefaultUserAgent unless WithUserAgent set another one.
+// The fallback keeps a Client built as a struct literal rather than with NewClient identifying itself.
+func (c *Client) userAgentValue() string {
+	if c.userAgent == "" {
+		return DefaultUserAgent
+	}
+	return c.userAgent
+}
+
+// buildURL joins the base URL and an endpoint path, appending the encoded query if it is not empty.
+// Slashes between BaseURL and path are normalized so that a base URL with or without a trailing slash behaves the same.
+func (c *Client) buildURL(path string, query url.Values) string {
+	u := strings.TrimSuffix(c.BaseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// apiURL builds the URL of an endpoint living under the API version, such as the photo and collection endpoints.
+// Slashes around Version are normalized the same way as around BaseURL.
+func (c *Client) apiURL(path string, query url.Values) string {
+	return c.buildURL(strings.Trim(c.Version, "/")+"/"+strings.TrimPrefix(path, "/"), query)
+}
+
+// getURL sends a GET request to an API URL taken from a response, such as a NextPage link, and decodes the response
+// into vals. Only the path and query of the link are trusted: unless it already points at the host of BaseURL, it is
+// rebased onto BaseURL, so the API key is never sent to another host and a proxy set with WithBaseURL is kept.
+func (c *Client) getURL(ctx context.Context, link string, vals interface{}) error {
+	u, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("pexels: invalid page URL %q: %w", link, err)
+	}
+	if !c.isAPIHost(u) {
+		link = c.buildURL(u.Path, nil)
+		if u.RawQuery != "" {
+			link += "?" + u.RawQuery
+		}
 	}
+	req, err := c.newRequest(ctx, http.MethodGet, link)
+	if err != nil {
+		return err
+	}
+	return c.sendRequest(ctx, req, vals)
 }
 
 // sendRequest sends an HTTP request to the Pexels API.
 // It takes a context, an HTTP request, and a variable to store the response data as input and returns an error.
+// Failed requests are retried according to WithRetry; the context deadline bounds the total time spent,
+// and cancelling the context aborts an in-flight request independently of the client timeout.
+// GET responses are served from and stored in the cache when one is configured with WithCache,
+// and revalidated with If-None-Match/If-Modified-Since when WithConditionalRequests is set.
+// A 204 No Content or otherwise empty success response leaves vals unchanged and returns nil.
 func (c *Client) sendRequest(ctx context.Context, req *http.Request, vals interface{}) error {
-	res, err := c.HTTPClient.Do(req)
+	key := ""
+	if req.Method == http.MethodGet {
+		key = req.URL.String()
+	}
+	meta := responseMetaFromContext(ctx)
+	if c.cache != nil && key != "" {
+		if body, ok := c.cache.Get(key); ok {
+			c.stats.cacheHit()
+			if meta != nil {
+				*meta = ResponseMeta{FromCache: true}
+			}
+			return c.decode(body, vals)
+		}
+	}
+	var stored *validator
+	if c.validators != nil && key != "" {
+		stored = c.validators.apply(key, req)
+	}
+	var start time.Time
+	if c.onComplete != nil {
+		start = time.Now()
+	}
+	res, body, err := c.sendWithRetry(ctx, req)
+	if c.onComplete != nil {
+		status := 0
+		if res != nil {
+			status = res.StatusCode
+		}
+		c.onComplete(req.URL.String(), status, time.Since(start))
+	}
+	if meta != nil {
+		*meta = ResponseMeta{}
+		if res != nil {
+			meta.StatusCode = res.StatusCode
+			meta.Response = res
+		}
+	}
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
-
-	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		bytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return err
+	if res.StatusCode == http.StatusNotModified {
+		if stored == nil {
+			return fmt.Errorf("pexels: unexpected %d response", res.StatusCode)
 		}
-		return fmt.Errorf("Unknown API error: %d %s", res.StatusCode, string(bytes))
+		body = stored.body
+	} else if res.StatusCode == http.StatusNoContent || len(body) == 0 {
+		// Nothing to decode: vals keeps its zero value and nothing is cached
+		return nil
+	} else if c.validators != nil && key != "" {
+		c.validators.store(key, res.Header, body)
 	}
-	if err := json.NewDecoder(res.Body).Decode(&vals); err != nil {
-		return err
+	if err := c.decode(body, vals); err != nil {
+		return fmt.Errorf("pexels: decoding %d response: %w: body: %q", res.StatusCode, err, truncate(body, maxErrorBodySnippet))
+	}
+	if c.cache != nil && key != "" {
+		if ttl := cacheTTL(res.Header, c.cacheTTL); ttl > 0 {
+			c.cache.Set(key, body, ttl)
+		}
 	}
 	return nil
 }
 
+// decode decodes a JSON response body into vals, rejecting fields vals does not model when WithStrictDecoding is set.
+func (c *Client) decode(body []byte, vals interface{}) error {
+	return decodeJSON(body, vals, c.strictDecoding)
+}
+
+// decodeJSON decodes data into v, rejecting unknown fields when strict is set.
+func decodeJSON(data []byte, v interface{}, strict bool) error {
+	if !strict {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// rawCapture decodes a response into v while keeping a copy of the JSON it was decoded from.
+type rawCapture struct {
+	raw    json.RawMessage // Copy of the JSON body
+	v      interface{}     // Value the body is decoded into
+	strict bool            // Reject unknown fields, see WithStrictDecoding
+}
+
+// UnmarshalJSON stores a copy of data and decodes it into the wrapped value.
+func (r *rawCapture) UnmarshalJSON(data []byte) error {
+	r.raw = append(json.RawMessage(nil), data...)
+	return decodeJSON(data, r.v, r.strict)
+}
+
+// maxRetryDelay is the longest Retry-After delay sendWithRetry waits for; a longer one returns the error at once,
+// since blocking a call for that long is worse than reporting the rate limit to the caller.
+const maxRetryDelay = time.Minute
+
+// sendWithRetry sends an HTTP request, retrying it according to WithRetry, and returns the final response and its body.
+func (c *Client) sendWithRetry(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	clock := c.timeSource()
+	for attempt := 1; ; attempt++ {
+		if c.throttle != nil {
+			d, err := c.throttle.delay(clock.Now())
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := clock.Sleep(ctx, d); err != nil {
+				return nil, nil, err
+			}
+		}
+		var start time.Time
+		if c.logger != nil {
+			start = time.Now()
+		}
+		res, body, err := c.doRequest(req)
+		c.stats.record(attempt, res)
+		if c.throttle != nil && res != nil {
+			c.throttle.update(res.Header)
+		}
+		if c.onRateLimit != nil && res != nil {
+			if rl, ok := parseRateLimit(res.Header); ok {
+				c.onRateLimit(rl)
+			}
+		}
+		if c.logger != nil {
+			c.logRequest(req, res, err, attempt, time.Since(start))
+		}
+		if err == nil || attempt >= c.maxAttempts || !isIdempotent(req.Method) {
+			return res, body, err
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || !c.isRetryableStatus(apiErr.StatusCode) {
+			return res, body, err
+		}
+		delay := apiErr.RetryAfter
+		if delay > maxRetryDelay {
+			return res, body, err
+		}
+		if delay <= 0 {
+			delay = c.backoff(attempt)
+		}
+		if deadline, ok := ctx.Deadline(); ok && deadline.Sub(clock.Now()) < delay {
+			return res, body, err
+		}
+		if err := clock.Sleep(ctx, delay); err != nil {
+			return res, body, err
+		}
+	}
+}
+
+// sleep pauses for d or until ctx is done, in which case it returns the context error.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// logRequest reports a request attempt to the WithLogger hook.
+func (c *Client) logRequest(req *http.Request, res *http.Response, err error, attempt int, latency time.Duration) {
+	info := RequestInfo{
+		Method:             req.Method,
+		URL:                req.URL.String(),
+		Latency:            latency,
+		Attempt:            attempt,
+		RateLimitRemaining: -1,
+		Err:                err,
+	}
+	if res != nil {
+		info.StatusCode = res.StatusCode
+		if rl, ok := parseRateLimit(res.Header); ok {
+			info.RateLimitRemaining = rl.Remaining
+		}
+	}
+	c.logger(info)
+}
+
+// doRequest performs a single attempt of an HTTP request and returns the response and its body.
+// The response body is already read and closed; non-2xx responses are returned as an APIError.
+func (c *Client) doRequest(req *http.Request) (*http.Response, []byte, error) {
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		closeResponse(res)
+		return nil, nil, err
+	}
+	defer drainAndClose(res.Body)
+
+	body, err := readLimited(res.Body, c.maxResponseBytes)
+	if err == nil {
+		body, err = decompress(res.Header.Get("Content-Encoding"), body, c.maxResponseBytes)
+	}
+	if err != nil {
+		return res, nil, err
+	}
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
+		return res, body, newAPIError(res, body, c.timeSource().Now())
+	}
+	return res, body, nil
+}
+
+// closeResponse closes the body of a response returned along with an error. *http.Client only does so with the
+// body already closed, but other Doer implementations may hand back an open one, which would leak its connection.
+func closeResponse(res *http.Response) {
+	if res != nil && res.Body != nil {
+		drainAndClose(res.Body)
+	}
+}
+
+// maxDrainBytes is the most drainAndClose reads from a body before closing it. Draining more than that costs
+// more than opening a new connection.
+const maxDrainBytes = 256 << 10
+
+// drainAndClose reads what is left of a response body, up to maxDrainBytes, and closes it. The HTTP transport
+// only reuses a keep-alive connection once its body has been read to the end, so every finished response goes
+// through here, including the error paths that stop reading early.
+func drainAndClose(body io.ReadCloser) {
+	io.CopyN(io.Discard, body, maxDrainBytes)
+	body.Close()
+}
+
+// readLimited reads r to the end, failing with ErrResponseTooLarge once more than limit bytes are read.
+// A limit of zero or less reads without limit.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err == nil && int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
+	}
+	return body, err
+}
+
+// maxErrorBodySnippet is the maximum number of body bytes included in decode errors.
+const maxErrorBodySnippet = 256
+
+// truncate returns b cut to at most n bytes, with an ellipsis appended when it was cut.
+func truncate(b []byte, n int) string {
+	if len(b) <= n {
+		return string(b)
+	}
+	return string(b[:n]) + "..."
+}
+
+// backoff returns the delay before retrying after the given attempt number, using the strategy set with WithBackoff
+// or, by default, exponential backoff from the WithRetry base delay with equal jitter, capped at maxRetryDelay like
+// Retry-After delays.
+func (c *Client) backoff(attempt int) time.Duration {
+	if c.backoffFn != nil {
+		return c.backoffFn(attempt)
+	}
+	return EqualJitter(c.baseDelay, maxRetryDelay)(attempt)
+}
+
+// isIdempotent reports whether requests with the given method may safely be sent more than once. Only reads are
+// retried: PUT and DELETE are idempotent in theory, but replaying a collection edit is not worth the risk.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	return false
+}
+
+// defaultRetryableStatus are the status codes retried by WithRetry unless overridden with WithRetryableStatus.
+var defaultRetryableStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// isRetryableStatus reports whether a request that failed with the given status code may be retried.
+func (c *Client) isRetryableStatus(code int) bool {
+	codes := c.retryStatus
+	if codes == nil {
+		codes = defaultRetryableStatus
+	}
+	for _, retryable := range codes {
+		if code == retryable {
+			return true
+		}
+	}
+	return false
+}
+
 // structToURLValues converts a struct to URL values for use in HTTP requests.
 // It takes a struct as input and returns URL values representing the struct fields.
+// Fields are encoded according to their url tag; a ",omitempty" suffix drops the field when it holds its zero value.
+// String, bool, signed and unsigned integer, and float fields are supported, other kinds are ignored.
+// Pointers to those kinds are dereferenced, so a non-nil pointer to a zero value is still encoded; nil pointers are skipped.
+// A url.Values field holds extra parameters merged into the result; explicit fields win on key collisions.
 func (c *Client) structToURLValues(s interface{}) url.Values {
 	val := url.Values{}
+	var extra url.Values
 	v := reflect.ValueOf(s)
 	t := reflect.TypeOf(s)
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
-		urlTag := t.Field(i).Tag.Get("url")
-		fieldValue := fmt.Sprint(field.Interface())
-		fieldKind := field.Kind()
-		if urlTag != "" && ((fieldKind == reflect.Int && fieldValue != "0") || (fieldKind == reflect.String && fieldValue != "")) {
-			val.Set(urlTag, fieldValue)
+		if field.Type() == reflect.TypeOf(url.Values(nil)) {
+			extra = field.Interface().(url.Values)
+			continue
+		}
+		name, opts, _ := strings.Cut(t.Field(i).Tag.Get("url"), ",")
+		if name == "" || (opts == "omitempty" && field.IsZero()) {
+			continue
+		}
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		switch field.Kind() {
+		case reflect.String:
+			val.Set(name, field.String())
+		case reflect.Bool:
+			val.Set(name, strconv.FormatBool(field.Bool()))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			val.Set(name, strconv.FormatInt(field.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			val.Set(name, strconv.FormatUint(field.Uint(), 10))
+		case reflect.Float32, reflect.Float64:
+			val.Set(name, strconv.FormatFloat(field.Float(), 'f', -1, field.Type().Bits()))
+		}
+	}
+	for key, values := range extra {
+		if _, ok := val[key]; !ok {
+			val[key] = values
 		}
 	}
 	return val
@@ -0,0 +1,1196 @@
+package pexels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFlakyServer returns a server that fails the first failures requests with status and then serves curated photos.
+func newFlakyServer(failures int32, status int) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"page":1,"per_page":1,"total_results":1,"photos":[{"id":1}]}`))
+	}))
+	return server, &calls
+}
+
+func TestRetryOnServerError(t *testing.T) {
+	server, calls := newFlakyServer(2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	// Initialize a client that retries up to three times
+	client := NewClient("key", WithRetry(3, time.Millisecond))
+	client.BaseURL = server.URL + "/"
+
+	resp, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{})
+	if err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	if len(resp.Photos) != 1 {
+		t.Errorf("GetCurated failed: expected 1 photo, got %d", len(resp.Photos))
+	}
+	if *calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", *calls)
+	}
+}
+
+func TestNoRetryByDefault(t *testing.T) {
+	server, calls := newFlakyServer(1, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := NewClient("key")
+	client.BaseURL = server.URL + "/"
+
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err == nil {
+		t.Errorf("GetCurated failed: expected an error")
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", *calls)
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	server, calls := newFlakyServer(1, http.StatusNotFound)
+	defer server.Close()
+
+	client := NewClient("key", WithRetry(3, time.Millisecond))
+	client.BaseURL = server.URL + "/"
+
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err == nil {
+		t.Errorf("GetCurated failed: expected an error")
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", *calls)
+	}
+}
+
+func TestNoRetryOnPost(t *testing.T) {
+	// Only reads are retried, so an edit is never replayed
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		server, calls := newFlakyServer(1, http.StatusServiceUnavailable)
+		client := NewClient("key", WithBaseURL(server.URL+"/"), WithRetry(3, time.Millisecond))
+		req, err := client.newRequest(context.Background(), method, client.buildURL("v1/collections", nil))
+		if err != nil {
+			t.Fatalf("newRequest failed: %v", err)
+		}
+		var resp GetCollectionsResponse
+		if err := client.sendRequest(context.Background(), req, &resp); err == nil {
+			t.Errorf("sendRequest failed: expected an error for a %s", method)
+		}
+		if *calls != 1 {
+			t.Errorf("expected 1 attempt for a %s, got %d", method, *calls)
+		}
+		server.Close()
+	}
+}
+
+func TestWithRetryableStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		status    int
+		wantCalls int32
+	}{
+		{"default retries 503", nil, http.StatusServiceUnavailable, 2},
+		{"default skips 408", nil, http.StatusRequestTimeout, 1},
+		{"default skips 501", nil, http.StatusNotImplemented, 1},
+		{"custom retries 408", []Option{WithRetryableStatus(http.StatusRequestTimeout)}, http.StatusRequestTimeout, 2},
+		{"custom skips 500", []Option{WithRetryableStatus(http.StatusRequestTimeout)}, http.StatusInternalServerError, 1},
+		{"empty set", []Option{WithRetryableStatus()}, http.StatusTooManyRequests, 1},
+	}
+	for _, tt := range tests {
+		server, calls := newFlakyServer(1, tt.status)
+		client := NewClient("key", append([]Option{WithBaseURL(server.URL + "/"), WithRetry(3, time.Millisecond)}, tt.opts...)...)
+		client.GetCurated(context.Background(), &GetCuratedPhotoParams{})
+		if *calls != tt.wantCalls {
+			t.Errorf("%s: got %d attempts, want %d", tt.name, *calls, tt.wantCalls)
+		}
+		server.Close()
+	}
+
+	for _, code := range []int{200, 399, 600} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("WithRetryableStatus(%d) did not panic", code)
+				}
+			}()
+			WithRetryableStatus(code)
+		}()
+	}
+}
+
+func TestRetryStopsOnContextDeadline(t *testing.T) {
+	server, calls := newFlakyServer(10, http.StatusTooManyRequests)
+	defer server.Close()
+
+	client := NewClient("key", WithRetry(5, time.Hour))
+	client.BaseURL = server.URL + "/"
+
+	// The backoff delay exceeds the deadline, so the client must give up after the first attempt
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := client.GetCurated(ctx, &GetCuratedPhotoParams{})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("GetCurated failed: expected ErrRateLimited, got %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("GetCurated waited past the context deadline")
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", *calls)
+	}
+}
+
+func TestStructToURLValues(t *testing.T) {
+	type params struct {
+		S     string  `url:"s,omitempty"`
+		B     bool    `url:"b,omitempty"`
+		I     int     `url:"i,omitempty"`
+		I8    int8    `url:"i8,omitempty"`
+		I64   int64   `url:"i64,omitempty"`
+		U     uint    `url:"u,omitempty"`
+		U32   uint32  `url:"u32,omitempty"`
+		F     float64 `url:"f,omitempty"`
+		F32   float32 `url:"f32,omitempty"`
+		Zero  int     `url:"zero"`
+		False bool    `url:"false"`
+		None  string
+		Extra url.Values
+	}
+
+	client := NewClient("key")
+	tests := []struct {
+		name   string
+		params params
+		want   string
+	}{
+		{"empty", params{}, "false=false&zero=0"},
+		{"string", params{S: "nature"}, "false=false&s=nature&zero=0"},
+		{"bool", params{B: true}, "b=true&false=false&zero=0"},
+		{"int", params{I: 42}, "false=false&i=42&zero=0"},
+		{"int8", params{I8: -8}, "false=false&i8=-8&zero=0"},
+		{"int64", params{I64: 1 << 40}, "false=false&i64=1099511627776&zero=0"},
+		{"uint", params{U: 7}, "false=false&u=7&zero=0"},
+		{"uint32", params{U32: 32}, "false=false&u32=32&zero=0"},
+		{"float64", params{F: 29.97}, "f=29.97&false=false&zero=0"},
+		{"float32", params{F32: 0.5}, "f32=0.5&false=false&zero=0"},
+		{"no omitempty", params{Zero: 3, False: true}, "false=true&zero=3"},
+		{"untagged", params{None: "ignored"}, "false=false&zero=0"},
+		{"extra", params{Extra: url.Values{"size": {"large"}, "tag": {"a", "b"}}}, "false=false&size=large&tag=a&tag=b&zero=0"},
+		{"extra collision", params{S: "nature", Extra: url.Values{"s": {"ocean"}, "zero": {"9"}}}, "false=false&s=nature&zero=0"},
+		{"extra fills omitted", params{Extra: url.Values{"s": {"ocean"}}}, "false=false&s=ocean&zero=0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.structToURLValues(tt.params).Encode(); got != tt.want {
+				t.Errorf("structToURLValues() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// newLiveClient returns a client for the live Pexels API, skipping the test when PEXELS_API_KEY is not set.
+func newLiveClient(t *testing.T) *Client {
+	t.Helper()
+	apiKey := os.Getenv("PEXELS_API_KEY")
+	if apiKey == "" {
+		t.Skip("PEXELS_API_KEY is not set")
+	}
+	return NewClient(apiKey)
+}
+
+// fakeDoer is a Doer that records the last request and replies with a canned response.
+type fakeDoer struct {
+	status int    // Status code of the canned response
+	body   string // Body of the canned response
+	req    *http.Request
+}
+
+func (f *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	f.req = req
+	return &http.Response{
+		StatusCode: f.status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(f.body)),
+		Request:    req,
+	}, nil
+}
+
+// newStubClient returns a client whose Doer records the outgoing request and replies with body.
+func newStubClient(body string, captured **http.Request, opts ...Option) *Client {
+	client := NewClient("key", opts...)
+	client.HTTPClient = doerFunc(func(req *http.Request) (*http.Response, error) {
+		*captured = req
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	return client
+}
+
+// doerFunc adapts a function to the Doer interface.
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newPagedServer returns a server holding items 1 to total split into pages of perPage, with next_page links
+// keeping the request path and query. Paths under /videos/ list videos, paths under /collections/ list the media of
+// the collection named by the last path segment, and every other path lists photos.
+// A page listed in failPages responds with 500 instead.
+func newPagedServer(total, perPage int, failPages ...int) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		for _, p := range failPages {
+			if p == page {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+		resp := map[string]interface{}{"page": page, "per_page": perPage, "total_results": total}
+		var photos []Photo
+		var videos []Video
+		var media []CollectionMedia
+		for id := (page-1)*perPage + 1; id <= total && id <= page*perPage; id++ {
+			photos = append(photos, Photo{ID: id})
+			videos = append(videos, Video{ID: id})
+			media = append(media, CollectionMedia{Type: "Photo", ID: id})
+		}
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/videos/"):
+			resp["videos"] = videos
+		case strings.Contains(r.URL.Path, "/collections/"):
+			resp["id"] = path.Base(r.URL.Path)
+			resp["media"] = media
+		default:
+			resp["photos"] = photos
+		}
+		if page*perPage < total {
+			q := r.URL.Query()
+			q.Set("page", strconv.Itoa(page+1))
+			resp["next_page"] = fmt.Sprintf("%s%s?%s", server.URL, r.URL.Path, q.Encode())
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	return server
+}
+
+func TestFakeDoerGetPhotos(t *testing.T) {
+	// Initialize a client with a fake Doer returning one photo
+	doer := &fakeDoer{
+		status: http.StatusOK,
+		body:   `{"total_results":1,"page":1,"per_page":5,"photos":[{"id":2014422,"width":3024,"height":3024}],"next_page":""}`,
+	}
+	client := NewClient("key", WithHTTPClient(doer))
+
+	resp, err := client.GetPhotos(context.Background(), &GetPhotosParams{Query: "nature"})
+	if err != nil {
+		t.Fatalf("GetPhotos failed: %v", err)
+	}
+	if resp.TotalResults != 1 || len(resp.Photos) != 1 || resp.Photos[0].ID != 2014422 {
+		t.Errorf("GetPhotos failed: unexpected response %+v", resp)
+	}
+	if got, want := doer.req.URL.String(), "https://api.pexels.com/v1/search?page=1&per_page=5&query=nature"; got != want {
+		t.Errorf("URL = %q, want %q", got, want)
+	}
+}
+
+func TestFakeDoerNotFound(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusNotFound, body: `{"error":"Not Found"}`}
+	client := NewClient("key", WithHTTPClient(doer))
+
+	resp, err := client.GetPhotos(context.Background(), &GetPhotosParams{Query: "nature"})
+	if resp != nil {
+		t.Errorf("GetPhotos failed: expected nil response, got %+v", resp)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("GetPhotos failed: expected 404 *APIError, got %v", err)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"default", nil, DefaultUserAgent},
+		{"custom", []Option{WithUserAgent("my-service/1.0")}, "my-service/1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			client := newStubClient(`{}`, &req, tt.opts...)
+			if _, err := client.GetPhoto(context.Background(), "1"); err != nil {
+				t.Fatalf("GetPhoto failed: %v", err)
+			}
+			if got := req.Header.Get("User-Agent"); got != tt.want {
+				t.Errorf("User-Agent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserAgentStructLiteralClient(t *testing.T) {
+	var agents []string
+	client := &Client{BaseURL: DefaultBaseURL, ApiKey: "key", Version: DefaultVersion, HTTPClient: doerFunc(func(req *http.Request) (*http.Response, error) {
+		agents = append(agents, req.Header.Get("User-Agent"))
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`)), Request: req}, nil
+	})}
+
+	// API requests, downloads and URL checks all fall back to the default User-Agent
+	if _, err := client.GetPhoto(context.Background(), "1"); err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+	if _, err := client.DownloadPhoto(context.Background(), "https://images.pexels.com/photos/1/a.jpeg", io.Discard); err != nil {
+		t.Fatalf("DownloadPhoto failed: %v", err)
+	}
+	if _, err := client.CheckURL(context.Background(), "https://images.pexels.com/photos/1/a.jpeg"); err != nil {
+		t.Fatalf("CheckURL failed: %v", err)
+	}
+	if len(agents) != 3 {
+		t.Fatalf("sent %d requests, want 3", len(agents))
+	}
+	for i, got := range agents {
+		if got != DefaultUserAgent {
+			t.Errorf("request %d User-Agent = %q, want %q", i, got, DefaultUserAgent)
+		}
+	}
+}
+
+func TestStandardHeaders(t *testing.T) {
+	ctx := context.Background()
+	calls := map[string]func(c *Client) error{
+		"GetPhotos": func(c *Client) error {
+			_, err := c.GetPhotos(ctx, &GetPhotosParams{Query: "nature"})
+			return err
+		},
+		"GetCurated": func(c *Client) error {
+			_, err := c.GetCurated(ctx, &GetCuratedPhotoParams{})
+			return err
+		},
+		"GetPhoto": func(c *Client) error {
+			_, err := c.GetPhoto(ctx, "1")
+			return err
+		},
+		"GetVideos": func(c *Client) error {
+			_, err := c.GetVideos(ctx, &GetVideosParams{Query: "nature"})
+			return err
+		},
+		"GetPopularVideos": func(c *Client) error {
+			_, err := c.GetPopularVideos(ctx, &GetPopularVideosParams{})
+			return err
+		},
+		"GetVideo": func(c *Client) error {
+			_, err := c.GetVideo(ctx, "1")
+			return err
+		},
+		"GetFeaturedCollections": func(c *Client) error {
+			_, err := c.GetFeaturedCollections(ctx, &GetFeaturedCollectionParams{})
+			return err
+		},
+		"GetUserCollections": func(c *Client) error {
+			_, err := c.GetUserCollections(ctx, &GetFeaturedCollectionParams{})
+			return err
+		},
+		"GetCollection": func(c *Client) error {
+			_, err := c.GetCollection(ctx, &GetCollectionMediaParams{}, "abc")
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			var req *http.Request
+			client := newStubClient(`{}`, &req)
+			if err := call(client); err != nil {
+				t.Fatalf("%s failed: %v", name, err)
+			}
+			for header, want := range map[string]string{
+				"Accept":        "application/json",
+				"Content-Type":  "",
+				"Authorization": "key",
+				"User-Agent":    DefaultUserAgent,
+			} {
+				if got := req.Header.Get(header); got != want {
+					t.Errorf("%s = %q, want %q", header, got, want)
+				}
+			}
+
+			// A custom auth header replaces Authorization
+			client = newStubClient(`{}`, &req, WithAuthHeader("X-Pexels-Key"))
+			if err := call(client); err != nil {
+				t.Fatalf("%s failed: %v", name, err)
+			}
+			if got := req.Header.Get("X-Pexels-Key"); got != "key" {
+				t.Errorf("X-Pexels-Key = %q, want %q", got, "key")
+			}
+			if got := req.Header.Get("Authorization"); got != "" {
+				t.Errorf("Authorization = %q, want it unset", got)
+			}
+
+			// A client built as a struct literal sends the key under the default header
+			client = &Client{BaseURL: DefaultBaseURL, ApiKey: "key", HTTPClient: client.HTTPClient, Version: DefaultVersion}
+			if err := call(client); err != nil {
+				t.Fatalf("%s failed: %v", name, err)
+			}
+			if got := req.Header.Get(DefaultAuthHeader); got != "key" {
+				t.Errorf("%s = %q on a struct literal client, want %q", DefaultAuthHeader, got, "key")
+			}
+		})
+	}
+}
+
+func TestBaseURLAndVersionOptions(t *testing.T) {
+	var req *http.Request
+	client := newStubClient(`{}`, &req, WithBaseURL("https://proxy.example.com/"), WithVersion("v2"))
+	if _, err := client.GetPhoto(context.Background(), "1"); err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+	if got, want := req.URL.String(), "https://proxy.example.com/v2/photos/1"; got != want {
+		t.Errorf("URL = %q, want %q", got, want)
+	}
+
+	// Clients created without options keep the defaults
+	if c := NewClient("key"); c.BaseURL != DefaultBaseURL || c.Version != DefaultVersion {
+		t.Errorf("NewClient() = %q %q, want defaults", c.BaseURL, c.Version)
+	}
+}
+
+func TestQueryFallback(t *testing.T) {
+	ctx := context.Background()
+
+	// Without the option an empty query is an error
+	var req *http.Request
+	client := newStubClient(`{}`, &req)
+	if _, err := client.GetPhotos(ctx, &GetPhotosParams{}); err == nil {
+		t.Errorf("GetPhotos failed: expected an error for an empty query")
+	}
+	if _, err := client.GetVideos(ctx, &GetVideosParams{}); err == nil {
+		t.Errorf("GetVideos failed: expected an error for an empty query")
+	}
+	if req != nil {
+		t.Errorf("expected no request to be sent")
+	}
+
+	// With the option empty queries are routed to the curated and popular endpoints
+	client = newStubClient(`{}`, &req, WithQueryFallback(true))
+	if _, err := client.GetPhotos(ctx, &GetPhotosParams{PerPage: Int(10)}); err != nil {
+		t.Fatalf("GetPhotos failed: %v", err)
+	}
+	if got, want := req.URL.String(), "https://api.pexels.com/v1/curated?page=1&per_page=10"; got != want {
+		t.Errorf("URL = %q, want %q", got, want)
+	}
+	if _, err := client.GetVideos(ctx, &GetVideosParams{}); err != nil {
+		t.Fatalf("GetVideos failed: %v", err)
+	}
+	if !strings.Contains(req.URL.Path, "videos/popular") {
+		t.Errorf("URL = %q, want the popular videos endpoint", req.URL)
+	}
+}
+
+func TestContextCancelAbortsRequest(t *testing.T) {
+	// Set up a server that never answers until the test is over
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	// Disable the transport timeout so only the context can abort the request
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithTimeout(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.GetCurated(ctx, &GetCuratedPhotoParams{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetCurated failed: expected context.Canceled, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("GetCurated was not aborted by the cancelled context")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	client := NewClient("key", WithTimeout(0))
+	if hc := client.HTTPClient.(*http.Client); hc.Timeout != 0 {
+		t.Errorf("Timeout = %v, want 0", hc.Timeout)
+	}
+	client = NewClient("key", WithTimeout(time.Second))
+	if hc := client.HTTPClient.(*http.Client); hc.Timeout != time.Second {
+		t.Errorf("Timeout = %v, want 1s", hc.Timeout)
+	}
+
+	// A client passed with WithHTTPClient is copied rather than modified
+	shared := &http.Client{Timeout: time.Minute}
+	client = NewClient("key", WithHTTPClient(shared), WithTimeout(time.Hour))
+	if shared.Timeout != time.Minute {
+		t.Errorf("shared Timeout = %v, want it left at 1m", shared.Timeout)
+	}
+	if hc := client.HTTPClient.(*http.Client); hc == shared || hc.Timeout != time.Hour {
+		t.Errorf("client Timeout = %v, want 1h on a copy", hc.Timeout)
+	}
+	NewClient("key", WithHTTPClient(http.DefaultClient), WithTimeout(time.Hour))
+	if http.DefaultClient.Timeout != 0 {
+		t.Errorf("http.DefaultClient.Timeout = %v, want it left at 0", http.DefaultClient.Timeout)
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	server, _ := newFlakyServer(0, http.StatusOK)
+	defer server.Close()
+
+	var calls int32
+	rt := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		req.Header.Set("X-Traced", "yes")
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	for _, opts := range [][]Option{
+		{WithTransport(rt), WithTimeout(time.Second)},
+		{WithTimeout(time.Second), WithTransport(rt)},
+	} {
+		client := NewClient("key", append(opts, WithBaseURL(server.URL+"/"))...)
+		if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+			t.Fatalf("GetCurated failed: %v", err)
+		}
+		if hc := client.HTTPClient.(*http.Client); hc.Timeout != time.Second {
+			t.Errorf("Timeout = %v, want 1s", hc.Timeout)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("transport invoked %d times, want 2", calls)
+	}
+
+	// A client passed with WithHTTPClient keeps its transport
+	shared := &http.Client{}
+	client := NewClient("key", WithHTTPClient(shared), WithTransport(rt))
+	if shared.Transport != nil {
+		t.Errorf("WithTransport changed the shared client's transport")
+	}
+	if _, ok := client.HTTPClient.(*http.Client).Transport.(roundTripperFunc); !ok {
+		t.Errorf("WithTransport did not set the transport on the client's copy")
+	}
+}
+
+func TestClone(t *testing.T) {
+	server, calls := newFlakyServer(0, http.StatusOK)
+	defer server.Close()
+
+	var logged int
+	base := NewClient("key", WithBaseURL(server.URL+"/"), WithTimeout(time.Minute))
+	clone := base.Clone(WithTimeout(time.Second), WithLogger(func(RequestInfo) { logged++ }))
+
+	if got := base.HTTPClient.(*http.Client).Timeout; got != time.Minute {
+		t.Errorf("base Timeout = %v, want 1m", got)
+	}
+	if got := clone.HTTPClient.(*http.Client).Timeout; got != time.Second {
+		t.Errorf("clone Timeout = %v, want 1s", got)
+	}
+	if base.HTTPClient.(*http.Client).Transport != clone.HTTPClient.(*http.Client).Transport {
+		t.Errorf("clone does not share the transport")
+	}
+
+	for _, c := range []*Client{base, clone} {
+		if _, err := c.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+			t.Fatalf("GetCurated failed: %v", err)
+		}
+	}
+	if logged != 1 {
+		t.Errorf("logger called %d times, want 1 (clone only)", logged)
+	}
+	if *calls != 2 || base.Stats().TotalRequests != 2 {
+		t.Errorf("got %d calls and %d counted requests, want 2 shared", *calls, base.Stats().TotalRequests)
+	}
+}
+
+func TestDecodeErrorIncludesBody(t *testing.T) {
+	// Set up a server returning an HTML maintenance page with a 200
+	page := "<html><body>Down for maintenance</body></html>" + strings.Repeat(" ", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	_, err := client.GetPhoto(context.Background(), "1")
+	if err == nil {
+		t.Fatal("GetPhoto failed: expected a decode error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "200") || !strings.Contains(msg, "Down for maintenance") {
+		t.Errorf("error %q does not include the status and body", msg)
+	}
+	if len(msg) > maxErrorBodySnippet+200 {
+		t.Errorf("error is %d bytes long, expected the body to be truncated", len(msg))
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv("MY_PEXELS_KEY", "secret")
+	client, err := NewClientFromEnv(WithAPIKeyEnv("MY_PEXELS_KEY"), WithUserAgent("my-service/1.0"))
+	if err != nil {
+		t.Fatalf("NewClientFromEnv failed: %v", err)
+	}
+	if client.ApiKey != "secret" || client.userAgent != "my-service/1.0" {
+		t.Errorf("NewClientFromEnv failed: options not applied, got key %q", client.ApiKey)
+	}
+
+	// A missing variable fails fast
+	t.Setenv("MY_PEXELS_KEY", "")
+	if _, err := NewClientFromEnv(WithAPIKeyEnv("MY_PEXELS_KEY")); err == nil {
+		t.Errorf("NewClientFromEnv failed: expected an error for a missing key")
+	}
+}
+
+func TestCheckAPIKey(t *testing.T) {
+	for _, key := range []string{"", "two words", "key\n"} {
+		var req *http.Request
+		client := newStubClient(`{}`, &req)
+		client.ApiKey = key
+		if err := client.CheckAPIKey(); !errors.Is(err, ErrInvalidAPIKey) {
+			t.Errorf("CheckAPIKey(%q) = %v, want ErrInvalidAPIKey", key, err)
+		}
+		if _, err := client.GetPhoto(context.Background(), "1"); !errors.Is(err, ErrInvalidAPIKey) {
+			t.Errorf("GetPhoto with key %q = %v, want ErrInvalidAPIKey", key, err)
+		}
+		if req != nil {
+			t.Errorf("a request was sent with key %q", key)
+		}
+	}
+}
+
+func TestPing(t *testing.T) {
+	server, calls := newCountingServer("")
+	defer server.Close()
+
+	// Ping reaches the API even when responses are cached
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithCache(time.Minute))
+	for i := 0; i < 2; i++ {
+		if err := client.Ping(context.Background()); err != nil {
+			t.Fatalf("Ping failed: %v", err)
+		}
+	}
+	if *calls != 2 {
+		t.Errorf("expected 2 requests, got %d", *calls)
+	}
+
+	var req *http.Request
+	client = newStubClient(`{"photos":[]}`, &req)
+	if err := client.Validate(context.Background()); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if got := req.URL.Query().Get("per_page"); got != "1" {
+		t.Errorf("per_page = %q, want 1", got)
+	}
+
+	// A key rejected by the API is reported as ErrInvalidAPIKey
+	doer := &fakeDoer{status: http.StatusUnauthorized}
+	client = NewClient("bad", WithHTTPClient(doer))
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("Ping failed: expected ErrInvalidAPIKey for a rejected key, got %v", err)
+	}
+}
+
+func TestTypedEnumsEncoding(t *testing.T) {
+	var req *http.Request
+	client := newStubClient(`{}`, &req)
+	params := &GetVideosParams{Query: "sea", Orientation: OrientationSquare, Size: SizeSmall}
+	if _, err := client.GetVideos(context.Background(), params); err != nil {
+		t.Fatalf("GetVideos failed: %v", err)
+	}
+	if q := req.URL.Query(); q.Get("orientation") != "square" || q.Get("size") != "small" {
+		t.Errorf("query = %q, want orientation=square and size=small", req.URL.RawQuery)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Remaining", "42")
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var infos []RequestInfo
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithRetry(2, time.Millisecond), WithLogger(func(info RequestInfo) {
+		infos = append(infos, info)
+	}))
+	if _, err := client.GetPhoto(context.Background(), "1"); err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+
+	// Both the failed attempt and the retry are logged
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(infos))
+	}
+	for i, want := range []int{http.StatusBadGateway, http.StatusOK} {
+		info := infos[i]
+		if info.Attempt != i+1 || info.StatusCode != want || info.Method != http.MethodGet || info.RateLimitRemaining != 42 {
+			t.Errorf("entry %d = %+v", i, info)
+		}
+		if info.URL != server.URL+"/v1/photos/1" {
+			t.Errorf("entry %d URL = %q", i, info.URL)
+		}
+	}
+	if infos[0].Err == nil || infos[1].Err != nil {
+		t.Errorf("unexpected errors: %v, %v", infos[0].Err, infos[1].Err)
+	}
+}
+
+func TestOnRequestComplete(t *testing.T) {
+	server, _ := newFlakyServer(1, http.StatusNotFound)
+	defer server.Close()
+
+	type call struct {
+		url    string
+		status int
+		dur    time.Duration
+	}
+	var calls []call
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithOnRequestComplete(func(url string, status int, dur time.Duration) {
+		calls = append(calls, call{url, status, dur})
+	}))
+
+	// Fires for failed and successful calls alike
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err == nil {
+		t.Fatal("GetCurated failed: expected a 404 error")
+	}
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+
+	// Fires with status 0 when no response is received
+	client.HTTPClient = doerFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	client.GetCurated(context.Background(), &GetCuratedPhotoParams{})
+
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(calls))
+	}
+	for i, want := range []int{http.StatusNotFound, http.StatusOK, 0} {
+		if calls[i].status != want || calls[i].dur <= 0 || !strings.HasPrefix(calls[i].url, server.URL+"/v1/curated") {
+			t.Errorf("call %d = %+v, want status %d", i, calls[i], want)
+		}
+	}
+}
+
+func TestRequestIDHeader(t *testing.T) {
+	var req *http.Request
+	client := newStubClient(`{}`, &req, WithRequestIDHeader("X-Request-ID"))
+
+	// No ID in the context, no header
+	if _, err := client.GetPhoto(context.Background(), "1"); err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+	if _, ok := req.Header["X-Request-Id"]; ok {
+		t.Errorf("X-Request-ID sent without an ID in the context")
+	}
+
+	ctx := ContextWithRequestID(context.Background(), "req-123")
+	if _, err := client.GetPhoto(ctx, "1"); err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+	if got := req.Header.Get("X-Request-ID"); got != "req-123" {
+		t.Errorf("X-Request-ID = %q, want req-123", got)
+	}
+
+	// Without the option the ID is not sent
+	client = newStubClient(`{}`, &req)
+	if _, err := client.GetPhoto(ctx, "1"); err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+	if got := req.Header.Get("X-Request-ID"); got != "" {
+		t.Errorf("X-Request-ID = %q, want unset", got)
+	}
+}
+
+func TestTransportTuning(t *testing.T) {
+	client := NewClient("key", WithMaxIdleConnsPerHost(32), WithForceHTTP1())
+	tr, ok := client.HTTPClient.(*http.Client).Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", client.HTTPClient.(*http.Client).Transport)
+	}
+	if tr.MaxIdleConnsPerHost != 32 || tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Errorf("transport not tuned: MaxIdleConnsPerHost %d, ForceAttemptHTTP2 %v", tr.MaxIdleConnsPerHost, tr.ForceAttemptHTTP2)
+	}
+	if http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost == 32 {
+		t.Errorf("http.DefaultTransport was modified")
+	}
+
+	// Tuning a clone leaves the original transport alone
+	clone := client.Clone(WithMaxIdleConnsPerHost(4))
+	if tr.MaxIdleConnsPerHost != 32 || clone.HTTPClient.(*http.Client).Transport.(*http.Transport).MaxIdleConnsPerHost != 4 {
+		t.Errorf("tuning the clone changed the original transport")
+	}
+
+	// A client passed with WithHTTPClient keeps its transport
+	sharedTransport := &http.Transport{MaxIdleConnsPerHost: 2}
+	shared := &http.Client{Transport: sharedTransport}
+	client = NewClient("key", WithHTTPClient(shared), WithMaxIdleConnsPerHost(16))
+	if shared.Transport != sharedTransport || sharedTransport.MaxIdleConnsPerHost != 2 {
+		t.Errorf("WithMaxIdleConnsPerHost changed the shared client or its transport")
+	}
+	if got := client.HTTPClient.(*http.Client).Transport.(*http.Transport).MaxIdleConnsPerHost; got != 16 {
+		t.Errorf("MaxIdleConnsPerHost = %d on the client's copy, want 16", got)
+	}
+
+	// A custom round tripper is left untouched
+	rt := roundTripperFunc(http.DefaultTransport.RoundTrip)
+	client = NewClient("key", WithTransport(rt), WithForceHTTP1())
+	if _, ok := client.HTTPClient.(*http.Client).Transport.(roundTripperFunc); !ok {
+		t.Errorf("WithForceHTTP1 replaced a custom transport")
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	const body = `{"page":1,"photos":[{"id":1,"unexpected":true}]}`
+	var req *http.Request
+
+	client := newStubClient(body, &req)
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Errorf("GetCurated failed in lenient mode: %v", err)
+	}
+
+	client = newStubClient(body, &req, WithStrictDecoding())
+	_, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{})
+	if err == nil || !strings.Contains(err.Error(), "unexpected") {
+		t.Errorf("GetCurated in strict mode: expected an unknown field error, got %v", err)
+	}
+	if _, _, err := client.GetPhotoRaw(context.Background(), "1"); err == nil {
+		t.Errorf("GetPhotoRaw in strict mode: expected an unknown field error")
+	}
+
+	client = newStubClient(`{"page":1,"photos":[{"id":1}]}`, &req, WithStrictDecoding())
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Errorf("GetCurated failed in strict mode on a known schema: %v", err)
+	}
+}
+
+func TestEmptyResponse(t *testing.T) {
+	for _, status := range []int{http.StatusNoContent, http.StatusOK} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		client := NewClient("key", WithBaseURL(server.URL+"/"), WithCache(time.Minute))
+		resp, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{})
+		server.Close()
+		if err != nil {
+			t.Errorf("GetCurated with an empty %d response failed: %v", status, err)
+			continue
+		}
+		if resp.Page != 0 || len(resp.Photos) != 0 {
+			t.Errorf("GetCurated with an empty %d response = %+v, want zero value", status, resp)
+		}
+	}
+}
+
+func TestAcceptLanguageFromLocale(t *testing.T) {
+	var req *http.Request
+	client := newStubClient(`{}`, &req, WithAcceptLanguageFromLocale())
+	if _, err := client.GetPhotos(context.Background(), &GetPhotosParams{Query: "praia", Locale: "pt-BR"}); err != nil {
+		t.Fatalf("GetPhotos failed: %v", err)
+	}
+	if got := req.Header.Get("Accept-Language"); got != "pt-BR" {
+		t.Errorf("Accept-Language = %q, want pt-BR", got)
+	}
+
+	// No locale, no header
+	if _, err := client.GetPhotos(context.Background(), &GetPhotosParams{Query: "beach"}); err != nil {
+		t.Fatalf("GetPhotos failed: %v", err)
+	}
+	if got := req.Header.Get("Accept-Language"); got != "" {
+		t.Errorf("Accept-Language = %q, want unset", got)
+	}
+
+	// Off by default
+	client = newStubClient(`{}`, &req)
+	if _, err := client.GetVideos(context.Background(), &GetVideosParams{Query: "praia", Locale: "pt-BR"}); err != nil {
+		t.Fatalf("GetVideos failed: %v", err)
+	}
+	if got := req.Header.Get("Accept-Language"); got != "" {
+		t.Errorf("Accept-Language = %q, want unset by default", got)
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	body := `{"page":1,"photos":[{"id":1,"alt":"` + strings.Repeat("x", 100) + `"}]}`
+	var req *http.Request
+
+	client := newStubClient(body, &req, WithMaxResponseBytes(50))
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("GetCurated = %v, want ErrResponseTooLarge", err)
+	}
+
+	client = newStubClient(body, &req, WithMaxResponseBytes(int64(len(body))))
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Errorf("GetCurated at exactly the limit failed: %v", err)
+	}
+
+	// No limit by default
+	client = newStubClient(body, &req)
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Errorf("GetCurated without a limit failed: %v", err)
+	}
+}
+
+func TestProxyBaseURL(t *testing.T) {
+	ctx := context.Background()
+	calls := map[string]func(c *Client) error{
+		"/v1/photos/2014422": func(c *Client) error {
+			_, err := c.GetPhoto(ctx, "2014422")
+			return err
+		},
+		"/v1/search": func(c *Client) error {
+			_, err := c.GetPhotos(ctx, &GetPhotosParams{Query: "nature"})
+			return err
+		},
+		"/v1/curated": func(c *Client) error {
+			_, err := c.GetCurated(ctx, &GetCuratedPhotoParams{})
+			return err
+		},
+		"/videos/videos/857251": func(c *Client) error {
+			_, err := c.GetVideo(ctx, "857251")
+			return err
+		},
+		"/videos/popular": func(c *Client) error {
+			_, err := c.GetPopularVideos(ctx, &GetPopularVideosParams{})
+			return err
+		},
+		"/v1/collections/featured": func(c *Client) error {
+			_, err := c.GetFeaturedCollections(ctx, &GetFeaturedCollectionParams{})
+			return err
+		},
+		"/v1/collections/abc": func(c *Client) error {
+			_, err := c.GetCollection(ctx, &GetCollectionMediaParams{}, "abc")
+			return err
+		},
+	}
+	for _, base := range []string{"http://pexels-proxy.internal", "http://pexels-proxy.internal/", "https://pexels-proxy.internal/"} {
+		for _, version := range []string{"v1", "/v1/"} {
+			for path, call := range calls {
+				var req *http.Request
+				client := newStubClient(`{}`, &req, WithBaseURL(base), WithVersion(version))
+				if err := call(client); err != nil {
+					t.Fatalf("%s with base %q failed: %v", path, base, err)
+				}
+				wantScheme, _, _ := strings.Cut(base, ":")
+				if req.URL.Scheme != wantScheme || req.URL.Host != "pexels-proxy.internal" || req.URL.Path != path {
+					t.Errorf("base %q, version %q: requested %s, want %s://pexels-proxy.internal%s", base, version, req.URL, wantScheme, path)
+				}
+			}
+		}
+	}
+}
+
+// trackedBody is a response body that records whether it was closed.
+type trackedBody struct {
+	io.Reader
+	closed atomic.Bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed.Store(true)
+	return nil
+}
+
+func TestCancelledContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	// Cancelling while waiting for the response
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithRetry(3, time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetCurated(ctx, &GetCuratedPhotoParams{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetCurated error = %v, want context.DeadlineExceeded", err)
+	}
+	if stats := client.Stats(); stats.TotalRequests != 1 {
+		t.Errorf("sent %d requests after the deadline, want 1", stats.TotalRequests)
+	}
+
+	// An already cancelled context fails before any request
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetCurated(ctx, &GetCuratedPhotoParams{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetCurated error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCancelledBodyIsClosed(t *testing.T) {
+	// A server that sends the headers and then stalls mid-body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"photos":[`))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	var bodies []*trackedBody
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithHTTPClient(doerFunc(func(req *http.Request) (*http.Response, error) {
+		res, err := http.DefaultClient.Do(req)
+		if err == nil {
+			body := &trackedBody{Reader: res.Body}
+			bodies = append(bodies, body)
+			res.Body = body
+		}
+		return res, err
+	})))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetCurated(ctx, &GetCuratedPhotoParams{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetCurated error = %v, want context.DeadlineExceeded", err)
+	}
+	if len(bodies) != 1 || !bodies[0].closed.Load() {
+		t.Errorf("response body was not closed after cancellation")
+	}
+
+	// A Doer returning a response along with an error must not leak its body
+	body := &trackedBody{Reader: strings.NewReader("")}
+	client = NewClient("key", WithHTTPClient(doerFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusFound, Body: body}, errors.New("redirect refused")
+	})))
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err == nil {
+		t.Errorf("GetCurated succeeded, want the Doer error")
+	}
+	if !body.closed.Load() {
+		t.Errorf("body of a response returned with an error was not closed")
+	}
+	body = &trackedBody{Reader: strings.NewReader("")}
+	if _, err := client.DownloadPhoto(context.Background(), "https://images.pexels.com/photos/1/x.jpeg", io.Discard); err == nil || !body.closed.Load() {
+		t.Errorf("DownloadPhoto = %v with body closed %v, want an error and a closed body", err, body.closed.Load())
+	}
+}
+
+func TestPerRequestHeaders(t *testing.T) {
+	var req *http.Request
+	client := newStubClient(`{}`, &req)
+	extra := http.Header{"x-trace-id": {"trace-1"}, "User-Agent": {"my-app/2.0"}}
+
+	if _, err := client.GetPhotosWithHeaders(context.Background(), &GetPhotosParams{Query: "sea"}, extra); err != nil {
+		t.Fatalf("GetPhotosWithHeaders failed: %v", err)
+	}
+	if got := req.Header.Get("X-Trace-Id"); got != "trace-1" {
+		t.Errorf("X-Trace-Id = %q, want trace-1", got)
+	}
+	if got := req.Header.Get("User-Agent"); got != "my-app/2.0" {
+		t.Errorf("User-Agent = %q, want the per-request override", got)
+	}
+	if got := req.Header.Get("Authorization"); got != "key" {
+		t.Errorf("Authorization = %q, want the standard header kept", got)
+	}
+
+	// Changing the header after the call has no effect on later calls through the context
+	ctx := ContextWithHeaders(context.Background(), extra)
+	extra.Set("X-Trace-Id", "trace-2")
+	if _, err := client.GetVideo(ctx, "1"); err != nil {
+		t.Fatalf("GetVideo failed: %v", err)
+	}
+	if got := req.Header.Get("X-Trace-Id"); got != "trace-1" {
+		t.Errorf("X-Trace-Id = %q, want the value at the time of ContextWithHeaders", got)
+	}
+
+	// Calls without the context send only the standard headers
+	if _, err := client.GetVideo(context.Background(), "1"); err != nil {
+		t.Fatalf("GetVideo failed: %v", err)
+	}
+	if got := req.Header.Get("X-Trace-Id"); got != "" || req.Header.Get("User-Agent") != DefaultUserAgent {
+		t.Errorf("headers leaked into a later call: %v", req.Header)
+	}
+}
+
+func TestConnectionReuse(t *testing.T) {
+	var conns atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/photos/404":
+			http.Error(w, strings.Repeat("not found ", 100), http.StatusNotFound)
+		case "/v1/photos/999":
+			w.Write([]byte(`{"id":1,"alt":"` + strings.Repeat("x", 100<<10) + `"}`))
+		default:
+			w.Write([]byte(`{"id":1}`))
+		}
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	// Successful calls, API errors and responses cut short by the size limit all leave the connection reusable
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithMaxResponseBytes(1<<10))
+	for _, id := range []string{"1", "404", "999", "1"} {
+		client.GetPhoto(context.Background(), id)
+	}
+	if n := conns.Load(); n != 1 {
+		t.Errorf("sequential calls opened %d connections, want 1", n)
+	}
+}
+
+func BenchmarkSequentialGetPhoto(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		client.GetPhoto(ctx, "1")
+	}
+}
@@ -0,0 +1,91 @@
+package pexelstest
+
+import (
+	"fmt"
+
+	pexels "github.com/nanorex07/pexels-go"
+)
+
+// cannedPhotos returns n photos with deterministic content and IDs starting at 1000.
+func cannedPhotos(n int) []pexels.Photo {
+	photos := make([]pexels.Photo, n)
+	for i := range photos {
+		id := 1000 + i
+		photos[i] = pexels.Photo{
+			ID:              id,
+			Width:           4000 + i,
+			Height:          3000,
+			URL:             fmt.Sprintf("https://www.pexels.com/photo/canned-photo-%d/", id),
+			Photographer:    "Canned Photographer",
+			PhotographerURL: "https://www.pexels.com/@canned",
+			PhotographerID:  42,
+			AvgColor:        "#7E8F9A",
+			Src:             cannedSrc(id),
+			Alt:             fmt.Sprintf("Canned photo %d", id),
+		}
+	}
+	return photos
+}
+
+// cannedSrc returns the size URLs of the photo with the given ID.
+func cannedSrc(id int) pexels.PhotoSrc {
+	base := fmt.Sprintf("https://images.pexels.com/photos/%d/pexels-photo-%d.jpeg", id, id)
+	return pexels.PhotoSrc{
+		Original:  base,
+		Large2X:   base + "?auto=compress&cs=tinysrgb&dpr=2&h=650&w=940",
+		Large:     base + "?auto=compress&cs=tinysrgb&h=650&w=940",
+		Medium:    base + "?auto=compress&cs=tinysrgb&h=350",
+		Small:     base + "?auto=compress&cs=tinysrgb&h=130",
+		Portrait:  base + "?auto=compress&cs=tinysrgb&fit=crop&h=1200&w=800",
+		Landscape: base + "?auto=compress&cs=tinysrgb&fit=crop&h=627&w=1200",
+		Tiny:      base + "?auto=compress&cs=tinysrgb&dpr=1&fit=crop&h=200&w=280",
+	}
+}
+
+// cannedVideos returns n videos with deterministic content and IDs starting at 2000.
+func cannedVideos(n int) []pexels.Video {
+	videos := make([]pexels.Video, n)
+	for i := range videos {
+		id := 2000 + i
+		videos[i] = pexels.Video{
+			ID:       id,
+			Width:    1920,
+			Height:   1080,
+			URL:      fmt.Sprintf("https://www.pexels.com/video/canned-video-%d/", id),
+			Image:    fmt.Sprintf("https://images.pexels.com/videos/%d/pictures/preview-0.jpg", id),
+			Tags:     []string{},
+			Duration: 10 + i,
+			User:     pexels.User{ID: 43, Name: "Canned Videographer", URL: "https://www.pexels.com/@canned-video"},
+			VideoFiles: []pexels.VideoFile{
+				{ID: id * 10, Quality: "hd", FileType: "video/mp4", Width: 1920, Height: 1080, Fps: 25, Link: fmt.Sprintf("https://player.vimeo.com/external/%d.hd.mp4", id)},
+				{ID: id*10 + 1, Quality: "sd", FileType: "video/mp4", Width: 640, Height: 360, Fps: 25, Link: fmt.Sprintf("https://player.vimeo.com/external/%d.sd.mp4", id)},
+			},
+			VideoPictures: []pexels.VideoPicture{
+				{ID: id * 10, Picture: fmt.Sprintf("https://images.pexels.com/videos/%d/pictures/preview-0.jpg", id), Nr: 0},
+				{ID: id*10 + 1, Picture: fmt.Sprintf("https://images.pexels.com/videos/%d/pictures/preview-1.jpg", id), Nr: 1},
+			},
+		}
+	}
+	return videos
+}
+
+// cannedCollections returns a public and a private collection.
+func cannedCollections() []pexels.Collection {
+	return []pexels.Collection{
+		{ID: "abc123", Title: "Canned Collection", Description: "Photos and videos", MediaCount: 12, PhotosCount: 8, VideosCount: 4},
+		{ID: "def456", Title: "Private Collection", Private: true},
+	}
+}
+
+// cannedMedia returns n media items, every third one a video.
+func cannedMedia(n int) []pexels.CollectionMedia {
+	media := make([]pexels.CollectionMedia, n)
+	for i := range media {
+		if i%3 == 2 {
+			media[i] = pexels.CollectionMedia{Type: "Video", ID: 3000 + i, Width: 1920, Height: 1080, Duration: 15, Tags: []string{}}
+			continue
+		}
+		media[i] = pexels.CollectionMedia{Type: "Photo", ID: 3000 + i, Width: 4000, Height: 3000, AvgColor: "#7E8F9A", Src: cannedSrc(3000 + i)}
+	}
+	return media
+}
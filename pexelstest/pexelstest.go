@@ -0,0 +1,244 @@
+// Package pexelstest provides an in-process fake of the Pexels API for tests.
+//
+// A Server serves canned photos, videos and collections over httptest, with the same pagination
+// and error behavior as the real API, so client code can be tested without network access or an API key.
+// RecordClient and ReplayClient instead record responses of the live API to a directory and serve them back.
+package pexelstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	pexels "github.com/nanorex07/pexels-go"
+)
+
+// Server is a fake Pexels API server preloaded with canned data.
+// The exported fields may be replaced before requests are made to change the served data.
+type Server struct {
+	*httptest.Server
+
+	Photos      []pexels.Photo                      // Photos served by the search, curated and photo endpoints
+	Videos      []pexels.Video                      // Videos served by the search, popular and video endpoints
+	Collections []pexels.Collection                 // Collections served by the featured and user collection endpoints
+	Media       map[string][]pexels.CollectionMedia // Media served for each collection ID
+
+	mu     sync.Mutex
+	status int
+}
+
+// NewServer starts a fake Pexels API server with canned data. The caller must Close it.
+func NewServer() *Server {
+	s := &Server{
+		Photos:      cannedPhotos(20),
+		Videos:      cannedVideos(10),
+		Collections: cannedCollections(),
+		Media:       map[string][]pexels.CollectionMedia{"abc123": cannedMedia(12)},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// NewClient starts a fake Pexels API server and returns a client pointed at it.
+// The server is closed when the test finishes.
+func NewClient(t testing.TB, opts ...pexels.Option) (*pexels.Client, *Server) {
+	t.Helper()
+	s := NewServer()
+	t.Cleanup(s.Close)
+	return s.Client(opts...), s
+}
+
+// Client returns a client pointed at the server, using a dummy API key.
+func (s *Server) Client(opts ...pexels.Option) *pexels.Client {
+	return pexels.NewClient("pexelstest", append([]pexels.Option{pexels.WithBaseURL(s.URL + "/")}, opts...)...)
+}
+
+// FailWith makes every subsequent request fail with the given HTTP status code. A status of 0 restores normal responses.
+func (s *Server) FailWith(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+// handle routes a request to the matching fake endpoint.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	status := s.status
+	s.mu.Unlock()
+	if status != 0 {
+		writeError(w, status)
+		return
+	}
+	if r.Header.Get("Authorization") == "" {
+		writeError(w, http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == "v1/search":
+		if r.URL.Query().Get("query") == "" {
+			writeError(w, http.StatusBadRequest)
+			return
+		}
+		s.writePhotos(w, r)
+	case path == "v1/curated":
+		s.writePhotos(w, r)
+	case strings.HasPrefix(path, "v1/photos/"):
+		s.writePhoto(w, strings.TrimPrefix(path, "v1/photos/"))
+	case path == "videos/search":
+		if r.URL.Query().Get("query") == "" {
+			writeError(w, http.StatusBadRequest)
+			return
+		}
+		s.writeVideos(w, r)
+	case path == "videos/popular":
+		s.writeVideos(w, r)
+	case strings.HasPrefix(path, "videos/videos/"):
+		s.writeVideo(w, strings.TrimPrefix(path, "videos/videos/"))
+	case path == "v1/collections" || path == "v1/collections/featured":
+		s.writeCollections(w, r)
+	case strings.HasPrefix(path, "v1/collections/"):
+		s.writeMedia(w, r, strings.TrimPrefix(path, "v1/collections/"))
+	default:
+		writeError(w, http.StatusNotFound)
+	}
+}
+
+// paginate returns the requested page and per page values and the bounds of that page within total items.
+// next and prev are the URLs of the neighbouring pages, empty when there is none.
+func (s *Server) paginate(r *http.Request, total int) (page, perPage, start, end int, next, prev string) {
+	q := r.URL.Query()
+	page, _ = strconv.Atoi(q.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ = strconv.Atoi(q.Get("per_page"))
+	if perPage < 1 {
+		perPage = 15
+	}
+	if perPage > pexels.MaxPerPage {
+		perPage = pexels.MaxPerPage
+	}
+	start = min((page-1)*perPage, total)
+	end = min(start+perPage, total)
+	link := func(p int) string {
+		q.Set("page", strconv.Itoa(p))
+		return fmt.Sprintf("%s%s?%s", s.URL, r.URL.Path, q.Encode())
+	}
+	if end < total {
+		next = link(page + 1)
+	}
+	if page > 1 {
+		prev = link(page - 1)
+	}
+	return page, perPage, start, end, next, prev
+}
+
+// writePhotos writes a page of photos.
+func (s *Server) writePhotos(w http.ResponseWriter, r *http.Request) {
+	page, perPage, start, end, next, prev := s.paginate(r, len(s.Photos))
+	writeJSON(w, pexels.GetPhotoResponse{
+		TotalResults: len(s.Photos),
+		Page:         page,
+		PerPage:      perPage,
+		Photos:       s.Photos[start:end],
+		NextPage:     next,
+		PrevPage:     prev,
+	})
+}
+
+// writePhoto writes the photo with the given ID.
+func (s *Server) writePhoto(w http.ResponseWriter, id string) {
+	for _, p := range s.Photos {
+		if strconv.Itoa(p.ID) == id {
+			writeJSON(w, p)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound)
+}
+
+// writeVideos writes a page of videos.
+func (s *Server) writeVideos(w http.ResponseWriter, r *http.Request) {
+	page, perPage, start, end, next, prev := s.paginate(r, len(s.Videos))
+	writeJSON(w, pexels.GetVideosResponse{
+		TotalResults: len(s.Videos),
+		Page:         page,
+		PerPage:      perPage,
+		URL:          s.URL + r.URL.Path,
+		Videos:       s.Videos[start:end],
+		NextPage:     next,
+		PrevPage:     prev,
+	})
+}
+
+// writeVideo writes the video with the given ID.
+func (s *Server) writeVideo(w http.ResponseWriter, id string) {
+	for _, v := range s.Videos {
+		if strconv.Itoa(v.ID) == id {
+			writeJSON(w, v)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound)
+}
+
+// writeCollections writes a page of collections.
+func (s *Server) writeCollections(w http.ResponseWriter, r *http.Request) {
+	page, perPage, start, end, next, prev := s.paginate(r, len(s.Collections))
+	writeJSON(w, pexels.GetCollectionsResponse{
+		Collections:  s.Collections[start:end],
+		Page:         page,
+		PerPage:      perPage,
+		TotalResults: len(s.Collections),
+		NextPage:     next,
+		PrevPage:     prev,
+	})
+}
+
+// writeMedia writes a page of the media of the collection with the given ID.
+func (s *Server) writeMedia(w http.ResponseWriter, r *http.Request, id string) {
+	media, ok := s.Media[id]
+	if !ok {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	if t := r.URL.Query().Get("type"); t != "" {
+		var filtered []pexels.CollectionMedia
+		for _, m := range media {
+			if strings.EqualFold(string(m.Type)+"s", t) {
+				filtered = append(filtered, m)
+			}
+		}
+		media = filtered
+	}
+	page, perPage, start, end, next, prev := s.paginate(r, len(media))
+	writeJSON(w, pexels.GetCollectionMedia{
+		ID:           id,
+		Media:        media[start:end],
+		Page:         page,
+		PerPage:      perPage,
+		TotalResults: len(media),
+		NextPage:     next,
+		PrevPage:     prev,
+	})
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the shape used by the Pexels API.
+func writeError(w http.ResponseWriter, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": http.StatusText(status)})
+}
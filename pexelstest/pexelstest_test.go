@@ -0,0 +1,72 @@
+package pexelstest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	pexels "github.com/nanorex07/pexels-go"
+)
+
+func TestServerPagination(t *testing.T) {
+	client, _ := NewClient(t)
+	ctx := context.Background()
+
+	// Walk every page of a photo search through NextPage
+	resp, err := client.GetPhotos(ctx, &pexels.GetPhotosParams{Query: "nature", PerPage: pexels.Int(8)})
+	if err != nil {
+		t.Fatalf("GetPhotos failed: %v", err)
+	}
+	if resp.TotalResults != 20 || len(resp.Photos) != 8 {
+		t.Fatalf("GetPhotos failed: got %d of %d photos", len(resp.Photos), resp.TotalResults)
+	}
+	page, ok := resp.NextPageNumber()
+	if !ok || page != 2 {
+		t.Errorf("NextPageNumber() = %d, %v, want 2, true", page, ok)
+	}
+	resp, err = client.GetPhotos(ctx, &pexels.GetPhotosParams{Query: "nature", PerPage: pexels.Int(8), Page: pexels.Int(3)})
+	if err != nil {
+		t.Fatalf("GetPhotos failed: %v", err)
+	}
+	if len(resp.Photos) != 4 || resp.NextPage != "" {
+		t.Errorf("last page has %d photos and next page %q", len(resp.Photos), resp.NextPage)
+	}
+
+	// Collection media are paged the same way
+	media, err := client.AllCollectionMedia(ctx, "abc123", &pexels.GetCollectionMediaParams{PerPage: pexels.Int(5)}, 0)
+	if err != nil {
+		t.Fatalf("AllCollectionMedia failed: %v", err)
+	}
+	if len(media) != 12 {
+		t.Errorf("AllCollectionMedia returned %d media, want 12", len(media))
+	}
+}
+
+func TestServerLookups(t *testing.T) {
+	client, _ := NewClient(t)
+	ctx := context.Background()
+
+	if photo, err := client.GetPhoto(ctx, "1000"); err != nil || photo.ID != 1000 {
+		t.Errorf("GetPhoto = %v, %v", photo, err)
+	}
+	if video, err := client.GetVideo(ctx, "2001"); err != nil || video.ID != 2001 {
+		t.Errorf("GetVideo = %v, %v", video, err)
+	}
+	var apiErr *pexels.APIError
+	if _, err := client.GetPhoto(ctx, "1"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("GetPhoto(unknown) = %v, want 404", err)
+	}
+}
+
+func TestServerFailWith(t *testing.T) {
+	client, server := NewClient(t)
+	server.FailWith(http.StatusTooManyRequests)
+	if _, err := client.GetCurated(context.Background(), &pexels.GetCuratedPhotoParams{}); !errors.Is(err, pexels.ErrRateLimited) {
+		t.Errorf("GetCurated failed: expected ErrRateLimited, got %v", err)
+	}
+	server.FailWith(0)
+	if _, err := client.GetCurated(context.Background(), &pexels.GetCuratedPhotoParams{}); err != nil {
+		t.Errorf("GetCurated failed: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package pexelstest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pexels "github.com/nanorex07/pexels-go"
+)
+
+// RecordEnv is the environment variable that makes NewRecordOrReplay record live responses instead of replaying them.
+const RecordEnv = "PEXELS_RECORD"
+
+// interaction is a recorded HTTP exchange as stored on disk, one JSON file per method and URL.
+type interaction struct {
+	Method string      `json:"method"` // HTTP method of the request
+	URL    string      `json:"url"`    // Path and query of the request, without scheme and host
+	Status int         `json:"status"` // Status code of the response
+	Header http.Header `json:"header"` // Headers of the response
+	Body   string      `json:"body"`   // Body of the response
+}
+
+// interactionKey returns the method and URL identifying a request in a recording. Scheme and host are left out,
+// so responses recorded against the live API replay for a client pointed at any base URL.
+func interactionKey(req *http.Request) (method, url string) {
+	return req.Method, req.URL.RequestURI()
+}
+
+// interactionFile returns the path of the recording of the request identified by method and url in dir.
+func interactionFile(dir, method, url string) string {
+	sum := sha256.Sum256([]byte(method + " " + url))
+	return filepath.Join(dir, strings.ToLower(method)+"-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// ReplayClient is a pexels.Doer serving responses recorded by RecordClient from Dir, so tests run offline and
+// deterministically. Requests are matched on method, path, and query; a request without a recording fails.
+type ReplayClient struct {
+	Dir string // Directory holding the recorded interactions
+}
+
+// Do returns the recorded response for req.
+func (c *ReplayClient) Do(req *http.Request) (*http.Response, error) {
+	method, url := interactionKey(req)
+	data, err := os.ReadFile(interactionFile(c.Dir, method, url))
+	if err != nil {
+		return nil, fmt.Errorf("pexelstest: no recorded response for %s %s, record one with %s=1: %w", method, url, RecordEnv, err)
+	}
+	var rec interaction
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("pexelstest: reading recorded response for %s %s: %w", method, url, err)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Header,
+		Body:          io.NopCloser(strings.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
+
+// RecordClient is a pexels.Doer that sends requests through Doer and saves every response to Dir for ReplayClient.
+// Only the response is recorded; request headers, including the API key, are never written to disk.
+type RecordClient struct {
+	Dir  string      // Directory the interactions are written to, created if needed
+	Doer pexels.Doer // Doer sending the live requests, http.DefaultClient if nil
+}
+
+// Do sends req and records its response before returning it.
+func (c *RecordClient) Do(req *http.Request) (*http.Response, error) {
+	doer := c.Doer
+	if doer == nil {
+		doer = http.DefaultClient
+	}
+	res, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	method, url := interactionKey(req)
+	header := res.Header.Clone()
+	header.Del("Content-Length")
+	data, err := json.MarshalIndent(interaction{Method: method, URL: url, Status: res.StatusCode, Header: header, Body: string(body)}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(interactionFile(c.Dir, method, url), data, 0o644); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// NewRecordOrReplay returns a RecordClient sending live requests through http.DefaultClient when the RecordEnv
+// environment variable is set, and a ReplayClient otherwise. Pass it to pexels.WithHTTPClient so a suite records
+// its responses into dir once, with RecordEnv and a real API key, and replays them offline on every other run.
+func NewRecordOrReplay(dir string) pexels.Doer {
+	if os.Getenv(RecordEnv) != "" {
+		return &RecordClient{Dir: dir}
+	}
+	return &ReplayClient{Dir: dir}
+}
@@ -0,0 +1,60 @@
+package pexelstest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	pexels "github.com/nanorex07/pexels-go"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// Record a search and a missing photo against the fake server
+	server := NewServer()
+	recorder := &RecordClient{Dir: dir, Doer: http.DefaultClient}
+	live := server.Client(pexels.WithHTTPClient(recorder))
+	want, err := live.GetPhotos(ctx, &pexels.GetPhotosParams{Query: "nature", PerPage: pexels.Int(3)})
+	if err != nil {
+		t.Fatalf("recording GetPhotos failed: %v", err)
+	}
+	if _, err := live.GetPhoto(ctx, "1"); err == nil {
+		t.Fatalf("recording GetPhoto(1) succeeded, want a 404")
+	}
+	server.Close()
+
+	// Replay them offline, with a client pointed at the default base URL
+	replay := pexels.NewClient("key", pexels.WithHTTPClient(&ReplayClient{Dir: dir}))
+	got, err := replay.GetPhotos(ctx, &pexels.GetPhotosParams{Query: "nature", PerPage: pexels.Int(3)})
+	if err != nil {
+		t.Fatalf("replaying GetPhotos failed: %v", err)
+	}
+	if len(got.Photos) != 3 || got.Photos[0].ID != want.Photos[0].ID || got.TotalResults != want.TotalResults {
+		t.Errorf("replayed %+v, want %+v", got, want)
+	}
+	var apiErr *pexels.APIError
+	if _, err := replay.GetPhoto(ctx, "1"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("replaying GetPhoto(1) = %v, want a 404 APIError", err)
+	}
+
+	// A request that was never recorded fails
+	if _, err := replay.GetPhotos(ctx, &pexels.GetPhotosParams{Query: "ocean"}); err == nil {
+		t.Errorf("replaying an unrecorded search succeeded")
+	}
+}
+
+func TestNewRecordOrReplay(t *testing.T) {
+	t.Setenv(RecordEnv, "")
+	os.Unsetenv(RecordEnv)
+	if _, ok := NewRecordOrReplay(t.TempDir()).(*ReplayClient); !ok {
+		t.Errorf("NewRecordOrReplay without %s is not a ReplayClient", RecordEnv)
+	}
+	t.Setenv(RecordEnv, "1")
+	if _, ok := NewRecordOrReplay(t.TempDir()).(*RecordClient); !ok {
+		t.Errorf("NewRecordOrReplay with %s is not a RecordClient", RecordEnv)
+	}
+}
@@ -2,8 +2,14 @@ package pexels
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"image/color"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // PhotoSrc represents the different sizes of a photo.
@@ -19,6 +25,48 @@ type PhotoSrc struct {
 	Tiny      string `json:"tiny"`      // URL to the tiny size photo
 }
 
+// PhotoSrcNames are the size names accepted by PhotoSrc.ByName, matching the JSON keys of PhotoSrc.
+var PhotoSrcNames = []string{"original", "large2x", "large", "medium", "small", "portrait", "landscape", "tiny"}
+
+// ByName returns the URL of the size with the given name, one of PhotoSrcNames, compared case-insensitively.
+// It returns false for an unknown name; the URL may be empty if the API did not provide that size.
+func (s PhotoSrc) ByName(name string) (string, bool) {
+	switch strings.ToLower(name) {
+	case "original":
+		return s.Original, true
+	case "large2x":
+		return s.Large2X, true
+	case "large":
+		return s.Large, true
+	case "medium":
+		return s.Medium, true
+	case "small":
+		return s.Small, true
+	case "portrait":
+		return s.Portrait, true
+	case "landscape":
+		return s.Landscape, true
+	case "tiny":
+		return s.Tiny, true
+	}
+	return "", false
+}
+
+// MarshalJSON encodes the photo sources, omitting sizes whose URL is empty to keep stored payloads small.
+// Decoding is unaffected: missing sizes decode as empty strings.
+func (s PhotoSrc) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Original  string `json:"original,omitempty"`
+		Large2X   string `json:"large2x,omitempty"`
+		Large     string `json:"large,omitempty"`
+		Medium    string `json:"medium,omitempty"`
+		Small     string `json:"small,omitempty"`
+		Portrait  string `json:"portrait,omitempty"`
+		Landscape string `json:"landscape,omitempty"`
+		Tiny      string `json:"tiny,omitempty"`
+	}(s))
+}
+
 // Photo represents a photo from the Pexels API.
 type Photo struct {
 	ID              int      `json:"id"`               // Unique identifier for the photo
@@ -34,21 +82,69 @@ type Photo struct {
 	Alt             string   `json:"alt"`              // Alternative description of the photo
 }
 
+// ColorRGBA parses the average color of the photo.
+// It returns the color with full alpha, or an error if AvgColor is not in the #rgb or #rrggbb form.
+func (p Photo) ColorRGBA() (color.RGBA, error) {
+	return parseHexColor(p.AvgColor)
+}
+
+// FilterPhotosByColorDistance returns the photos whose average color lies within maxDist of target, measured as the
+// Euclidean distance between the red, green and blue components (0 to about 441.7). It refines the coarse color
+// buckets of the search API client-side. Photos whose AvgColor cannot be parsed are skipped, and the alpha of target
+// is ignored. The input slice is not modified.
+func FilterPhotosByColorDistance(photos []Photo, target color.RGBA, maxDist float64) []Photo {
+	var matched []Photo
+	for _, p := range photos {
+		c, err := p.ColorRGBA()
+		if err != nil || colorDistance(c, target) > maxDist {
+			continue
+		}
+		matched = append(matched, p)
+	}
+	return matched
+}
+
+// colorDistance returns the Euclidean distance between the RGB components of a and b.
+func colorDistance(a, b color.RGBA) float64 {
+	dr := float64(a.R) - float64(b.R)
+	dg := float64(a.G) - float64(b.G)
+	db := float64(a.B) - float64(b.B)
+	return math.Sqrt(dr*dr + dg*dg + db*db)
+}
+
+// parseHexColor parses a hexadecimal color code in the #rgb or #rrggbb form into a color.RGBA with full alpha.
+func parseHexColor(s string) (color.RGBA, error) {
+	hex, ok := strings.CutPrefix(s, "#")
+	if !ok || (len(hex) != 3 && len(hex) != 6) {
+		return color.RGBA{}, fmt.Errorf("pexels: invalid hex color %q", s)
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("pexels: invalid hex color %q", s)
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
+
 // GetPhotosParams represents the parameters for the GetPhotos function.
 type GetPhotosParams struct {
-	Query       string `url:"query"`       // Search query for photos
-	Orientation string `url:"orientation"` // Desired orientation of photos (e.g., landscape, portrait)
-	Size        string `url:"size"`        // Desired size of photos (e.g., small, medium, large)
-	Color       string `url:"color"`       // Desired color of photos (e.g., red, blue, green)
-	Locale      string `url:"locale"`      // Locale for the search query
-	Page        int    `url:"page"`        // Page number for paginated results
-	PerPage     int    `url:"per_page"`    // Number of results per page
+	Query       string      `url:"query,omitempty"`       // Search query for photos
+	Orientation Orientation `url:"orientation,omitempty"` // Desired orientation of photos (e.g., landscape, portrait)
+	Size        Size        `url:"size,omitempty"`        // Desired size of photos (e.g., small, medium, large)
+	Color       string      `url:"color,omitempty"`       // Desired color of photos: a named color (e.g., red, blue) or a hex code such as #ff0000, with or without the #
+	Locale      string      `url:"locale,omitempty"`      // Locale for the search query, one of SupportedLocales
+	Page        *int        `url:"page,omitempty"`        // Page number for paginated results, 1 when nil
+	PerPage     *int        `url:"per_page,omitempty"`    // Number of results per page, the endpoint default when nil, clamped to MaxPerPage
+	Extra       url.Values  // Additional query parameters; explicit fields take precedence on key collisions
 }
 
 // GetCuratedPhotoParams represents the parameters for the GetCurated function.
 type GetCuratedPhotoParams struct {
-	Page    int `url:"page"`     // Page number for paginated results
-	PerPage int `url:"per_page"` // Number of results per page
+	Page    *int       `url:"page,omitempty"`     // Page number for paginated results, 1 when nil
+	PerPage *int       `url:"per_page,omitempty"` // Number of results per page, the endpoint default when nil, clamped to MaxPerPage
+	Extra   url.Values // Additional query parameters; explicit fields take precedence on key collisions
 }
 
 // GetPhotoResponse represents the response from the GetPhotos function.
@@ -65,24 +161,30 @@ type GetPhotoResponse struct {
 // It takes a context and GetPhotosParams as input and returns a GetPhotoResponse and an error.
 // The GetPhotosParams specify the search query, orientation, size, color, locale, page, and per page parameters.
 // The GetPhotoResponse contains the total number of results, the current page number, the number of results per page, a list of photos matching the query, and URLs to the next and previous pages of results.
+// An empty query is an error unless the client was created with WithQueryFallback, in which case curated photos are returned.
 func (c *Client) GetPhotos(ctx context.Context, params *GetPhotosParams) (*GetPhotoResponse, error) {
-	if params.Page == 0 {
-		params.Page = 1
-	}
-	if params.PerPage == 0 {
-		params.PerPage = 5
+	if err := c.applyPaging(&params.Page, &params.PerPage, 5); err != nil {
+		return nil, err
 	}
 	if params.Query == "" {
+		if c.queryFallback {
+			return c.GetCurated(ctx, &GetCuratedPhotoParams{Page: params.Page, PerPage: params.PerPage, Extra: params.Extra})
+		}
 		return nil, fmt.Errorf("Query field cannot be empty.")
 	}
-	url := fmt.Sprintf("%s%s/search?%s", c.BaseURL, c.Version, c.structToURLValues(*params).Encode())
-	req, err := http.NewRequest("GET", url, nil)
+	c.applySearchDefaults(&params.Orientation, &params.Size)
+	params.normalize()
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.validateLocale(params.Locale); err != nil {
+		return nil, err
+	}
+	url := c.apiURL("search", c.structToURLValues(*params))
+	req, err := c.newRequest(ctx, http.MethodGet, url)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", c.ApiKey)
 
 	var resp GetPhotoResponse = GetPhotoResponse{}
 	err = c.sendRequest(ctx, req, &resp)
@@ -97,21 +199,14 @@ func (c *Client) GetPhotos(ctx context.Context, params *GetPhotosParams) (*GetPh
 // The GetCuratedPhotoParams specify the page and per page parameters.
 // The GetPhotoResponse contains the total number of results, the current page number, the number of results per page, a list of photos matching the query, and URLs to the next and previous pages of results.
 func (c *Client) GetCurated(ctx context.Context, params *GetCuratedPhotoParams) (*GetPhotoResponse, error) {
-	if params.Page == 0 {
-		params.Page = 1
-	}
-	if params.PerPage == 0 {
-		params.PerPage = 5
+	if err := c.applyPaging(&params.Page, &params.PerPage, 5); err != nil {
+		return nil, err
 	}
-	url := fmt.Sprintf("%s%s/curated?%s", c.BaseURL, c.Version, c.structToURLValues(*params).Encode())
-	req, err := http.NewRequest("GET", url, nil)
+	url := c.apiURL("curated", c.structToURLValues(*params))
+	req, err := c.newRequest(ctx, http.MethodGet, url)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.ApiKey)
 
 	var resp GetPhotoResponse = GetPhotoResponse{}
 	err = c.sendRequest(ctx, req, &resp)
@@ -123,18 +218,13 @@ func (c *Client) GetCurated(ctx context.Context, params *GetCuratedPhotoParams)
 
 // GetPhoto retrieves a photo from the Pexels API.
 // It takes a context and an ID as input and returns a Photo and an error.
-// The ID is the unique identifier for the photo.
+// The ID is the unique identifier for the photo; an empty or non-numeric ID returns an error wrapping ErrInvalidID without a request.
 // The Photo contains the ID, width, height, URL, photographer, photographer URL, photographer ID, average color, source, liked status, and alternative description of the photo.
 func (c *Client) GetPhoto(ctx context.Context, id string) (*Photo, error) {
-	url := fmt.Sprintf("%s%s/photos/%s", c.BaseURL, c.Version, id)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := c.newPhotoRequest(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.ApiKey)
 
 	var resp Photo = Photo{}
 	err = c.sendRequest(ctx, req, &resp)
@@ -143,3 +233,39 @@ func (c *Client) GetPhoto(ctx context.Context, id string) (*Photo, error) {
 	}
 	return &resp, nil
 }
+
+// GetPhotoWithResponse is like GetPhoto but also returns the HTTP response, to inspect headers beyond the rate limits.
+// The response body has already been read and closed. The response is nil when the photo was served from the cache
+// set with WithCache; on an API error it is returned along with the error. Other methods can expose their response
+// the same way through ContextWithResponseMeta.
+func (c *Client) GetPhotoWithResponse(ctx context.Context, id string) (*Photo, *http.Response, error) {
+	var meta ResponseMeta
+	photo, err := c.GetPhoto(ContextWithResponseMeta(ctx, &meta), id)
+	return photo, meta.Response, err
+}
+
+// GetPhotoRaw is like GetPhoto but also returns the JSON body of the response as received, for debugging or to read
+// fields that Photo does not model yet. The client keeps no reference to the body; it stays in memory only as long
+// as the caller holds the returned RawMessage.
+func (c *Client) GetPhotoRaw(ctx context.Context, id string) (*Photo, json.RawMessage, error) {
+	req, err := c.newPhotoRequest(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp := Photo{}
+	raw := rawCapture{v: &resp, strict: c.strictDecoding}
+	if err := c.sendRequest(ctx, req, &raw); err != nil {
+		return nil, nil, err
+	}
+	return &resp, raw.raw, nil
+}
+
+// newPhotoRequest validates a photo ID and creates the request retrieving that photo.
+func (c *Client) newPhotoRequest(ctx context.Context, id string) (*http.Request, error) {
+	if err := validateID("photo", id); err != nil {
+		return nil, err
+	}
+	url := c.apiURL("photos/"+id, nil)
+	return c.newRequest(ctx, http.MethodGet, url)
+}
@@ -2,13 +2,17 @@ package pexels
 
 import (
 	"context"
-	"os"
+	"encoding/json"
+	"image/color"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
 func TestGetPhotos(t *testing.T) {
 	// Initialize a new Pexels API client
-	client := NewClient(os.Getenv("PEXELS_API_KEY"))
+	client := newLiveClient(t)
 
 	// Set up the parameters for the GetPhotos function
 	params := &GetPhotosParams{
@@ -17,8 +21,8 @@ func TestGetPhotos(t *testing.T) {
 		Size:        "medium",
 		Color:       "green",
 		Locale:      "en-US",
-		Page:        1,
-		PerPage:     10,
+		Page:        Int(1),
+		PerPage:     Int(10),
 	}
 
 	// Call the GetPhotos function
@@ -38,12 +42,12 @@ func TestGetPhotos(t *testing.T) {
 
 func TestGetCurated(t *testing.T) {
 	// Initialize a new Pexels API client
-	client := NewClient(os.Getenv("PEXELS_API_KEY"))
+	client := newLiveClient(t)
 
 	// Set up the parameters for the GetCurated function
 	params := &GetCuratedPhotoParams{
-		Page:    1,
-		PerPage: 10,
+		Page:    Int(1),
+		PerPage: Int(10),
 	}
 
 	// Call the GetCurated function
@@ -63,7 +67,7 @@ func TestGetCurated(t *testing.T) {
 
 func TestGetPhoto(t *testing.T) {
 	// Initialize a new Pexels API client
-	client := NewClient(os.Getenv("PEXELS_API_KEY"))
+	client := newLiveClient(t)
 
 	// Set up the parameters for the GetPhoto function
 	id := "2014422"
@@ -79,3 +83,159 @@ func TestGetPhoto(t *testing.T) {
 		t.Errorf("GetPhoto failed: response is nil")
 	}
 }
+
+func TestPhotoColorRGBA(t *testing.T) {
+	tests := []struct {
+		avgColor string
+		want     color.RGBA
+		wantErr  bool
+	}{
+		{"#978E82", color.RGBA{R: 0x97, G: 0x8e, B: 0x82, A: 0xff}, false},
+		{"#000000", color.RGBA{A: 0xff}, false},
+		{"#abc", color.RGBA{R: 0xaa, G: 0xbb, B: 0xcc, A: 0xff}, false},
+		{"978E82", color.RGBA{}, true},
+		{"#978E8", color.RGBA{}, true},
+		{"#zzzzzz", color.RGBA{}, true},
+		{"", color.RGBA{}, true},
+	}
+	for _, tt := range tests {
+		got, err := Photo{AvgColor: tt.avgColor}.ColorRGBA()
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ColorRGBA(%q) = %v, %v, want %v, wantErr %v", tt.avgColor, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestGetRaw(t *testing.T) {
+	const body = `{"id":1,"width":10,"new_field":{"nested":true}}`
+	var req *http.Request
+	client := newStubClient(body, &req)
+
+	photo, raw, err := client.GetPhotoRaw(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetPhotoRaw failed: %v", err)
+	}
+	if photo.ID != 1 || photo.Width != 10 || string(raw) != body {
+		t.Errorf("GetPhotoRaw = %+v, %s", photo, raw)
+	}
+
+	video, raw, err := client.GetVideoRaw(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetVideoRaw failed: %v", err)
+	}
+	if video.ID != 1 || video.Width != 10 || string(raw) != body {
+		t.Errorf("GetVideoRaw = %+v, %s", video, raw)
+	}
+
+	if _, _, err := client.GetPhotoRaw(context.Background(), ""); err == nil {
+		t.Errorf("GetPhotoRaw accepted an empty ID")
+	}
+}
+
+func TestPhotoSrcMarshalOmitsEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		src  PhotoSrc
+		want string
+	}{
+		{"empty", PhotoSrc{}, `{}`},
+		{"partial", PhotoSrc{Original: "o.jpg", Tiny: "t.jpg"}, `{"original":"o.jpg","tiny":"t.jpg"}`},
+		{"full", PhotoSrc{"o", "l2", "l", "m", "s", "p", "ls", "t"}, `{"original":"o","large2x":"l2","large":"l","medium":"m","small":"s","portrait":"p","landscape":"ls","tiny":"t"}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.src)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: Marshal = %s, want %s", tt.name, got, tt.want)
+		}
+
+		// Round trips to the same value
+		var back PhotoSrc
+		if err := json.Unmarshal(got, &back); err != nil || back != tt.src {
+			t.Errorf("%s: Unmarshal = %+v, %v, want %+v", tt.name, back, err, tt.src)
+		}
+	}
+
+	// Nested in a Photo, the empty sizes are dropped too
+	got, _ := json.Marshal(Photo{ID: 1, Src: PhotoSrc{Medium: "m.jpg"}})
+	if !strings.Contains(string(got), `"src":{"medium":"m.jpg"}`) {
+		t.Errorf("Marshal(Photo) = %s, want compact src", got)
+	}
+}
+
+func TestPhotoSrcByName(t *testing.T) {
+	src := PhotoSrc{"o", "l2", "l", "m", "s", "p", "ls", "t"}
+	want := []string{"o", "l2", "l", "m", "s", "p", "ls", "t"}
+	for i, name := range PhotoSrcNames {
+		if got, ok := src.ByName(name); !ok || got != want[i] {
+			t.Errorf("ByName(%q) = %q, %v, want %q", name, got, ok, want[i])
+		}
+	}
+	if got, ok := src.ByName("LARGE2X"); !ok || got != "l2" {
+		t.Errorf("ByName(LARGE2X) = %q, %v, want l2", got, ok)
+	}
+	if _, ok := src.ByName("huge"); ok {
+		t.Errorf("ByName(huge) found a size")
+	}
+}
+
+func TestGetPhotoWithResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		if r.URL.Path == "/v1/photos/404" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":2014422}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	photo, res, err := client.GetPhotoWithResponse(context.Background(), "2014422")
+	if err != nil || photo.ID != 2014422 {
+		t.Fatalf("GetPhotoWithResponse = %+v, %v", photo, err)
+	}
+	if res == nil || res.Header.Get("X-Request-Id") != "req-42" {
+		t.Errorf("GetPhotoWithResponse returned response %+v, want the X-Request-Id header", res)
+	}
+
+	// The response comes along with API errors too
+	_, res, err = client.GetPhotoWithResponse(context.Background(), "404")
+	if err == nil || res == nil || res.StatusCode != http.StatusNotFound {
+		t.Errorf("GetPhotoWithResponse on a missing photo = %v, %v, want a 404 response and an error", res, err)
+	}
+}
+
+func TestFilterPhotosByColorDistance(t *testing.T) {
+	photos := []Photo{
+		{ID: 1, AvgColor: "#FF0000"},
+		{ID: 2, AvgColor: "#F00A0A"}, // sqrt(15² + 10² + 10²) ≈ 20.6 from red
+		{ID: 3, AvgColor: "#0000FF"},
+		{ID: 4, AvgColor: "not a color"},
+		{ID: 5, AvgColor: "#f00"},
+	}
+	red := color.RGBA{R: 255, A: 255}
+	tests := []struct {
+		maxDist float64
+		want    []int
+	}{
+		{0, []int{1, 5}},
+		{20, []int{1, 5}},
+		{21, []int{1, 2, 5}},
+		{500, []int{1, 2, 3, 5}},
+	}
+	for _, tt := range tests {
+		got := FilterPhotosByColorDistance(photos, red, tt.maxDist)
+		if len(got) != len(tt.want) {
+			t.Errorf("maxDist %g: got %d photos, want %v", tt.maxDist, len(got), tt.want)
+			continue
+		}
+		for i, id := range tt.want {
+			if got[i].ID != id {
+				t.Errorf("maxDist %g: photo %d = %d, want %d", tt.maxDist, i, got[i].ID, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package pexels
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit represents the rate limit state reported by the Pexels API in the X-Ratelimit-* response headers.
+type RateLimit struct {
+	Limit     int       // Total number of requests allowed in the current period
+	Remaining int       // Number of requests remaining in the current period
+	Reset     time.Time // Time at which the current period ends
+}
+
+// parseRateLimit parses the X-Ratelimit-* headers of a response.
+// It returns false when the response carries no rate limit headers.
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	remaining, err := strconv.Atoi(header.Get("X-Ratelimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	limit, _ := strconv.Atoi(header.Get("X-Ratelimit-Limit"))
+	return RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     parseRateLimitReset(header.Get("X-Ratelimit-Reset")),
+	}, true
+}
+
+// throttleThreshold is the fraction of the rate limit below which WithAutoThrottle starts spacing requests.
+const throttleThreshold = 0.1
+
+// throttle tracks the latest rate limit reported by the API to space out requests. It is safe for concurrent use.
+type throttle struct {
+	mu        sync.Mutex
+	rateLimit RateLimit
+	known     bool
+}
+
+// update records the rate limit reported in a response's headers.
+func (t *throttle) update(header http.Header) {
+	rl, ok := parseRateLimit(header)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rateLimit = rl
+	t.known = true
+}
+
+// delay returns how long to wait before the next request so that the remaining quota is spread evenly until the reset.
+// It is zero while the remaining quota is above throttleThreshold of the limit, or when the limit is unknown or no
+// rate limit has been seen yet. X-Ratelimit-Reset is the monthly quota rollover, so the delay is capped at
+// maxRetryDelay, and an exhausted quota that resets later than that returns ErrRateLimited rather than blocking.
+// now is the current time of the client's Clock.
+func (t *throttle) delay(now time.Time) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.known || t.rateLimit.Reset.IsZero() || t.rateLimit.Limit <= 0 {
+		return 0, nil
+	}
+	until := t.rateLimit.Reset.Sub(now)
+	if until <= 0 {
+		return 0, nil
+	}
+	if float64(t.rateLimit.Remaining) >= throttleThreshold*float64(t.rateLimit.Limit) {
+		return 0, nil
+	}
+	if t.rateLimit.Remaining <= 0 {
+		if until > maxRetryDelay {
+			return 0, fmt.Errorf("%w: quota exhausted until %s", ErrRateLimited, t.rateLimit.Reset.Format(time.RFC3339))
+		}
+		return until, nil
+	}
+	return min(until/time.Duration(t.rateLimit.Remaining+1), maxRetryDelay), nil
+}
+
+// ThrottleDelay returns the delay WithAutoThrottle currently applies before each request.
+// It is always zero when auto throttling is disabled, and also while requests fail with ErrRateLimited because the
+// quota is exhausted and resets more than a minute from now.
+func (c *Client) ThrottleDelay() time.Duration {
+	if c.throttle == nil {
+		return 0
+	}
+	d, _ := c.throttle.delay(c.timeSource().Now())
+	return d
+}
@@ -0,0 +1,149 @@
+package pexels
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rateLimitHeader returns rate limit headers with the given values and a reset time resetIn from now.
+func rateLimitHeader(limit, remaining int, resetIn time.Duration) http.Header {
+	h := http.Header{}
+	h.Set("X-Ratelimit-Limit", strconv.Itoa(limit))
+	h.Set("X-Ratelimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-Ratelimit-Reset", strconv.FormatInt(time.Now().Add(resetIn).Unix(), 10))
+	return h
+}
+
+func TestThrottleDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  http.Header
+		wantMin time.Duration
+		wantMax time.Duration
+		wantErr bool
+	}{
+		{"no headers", http.Header{}, 0, 0, false},
+		{"plenty remaining", rateLimitHeader(100, 50, time.Minute), 0, 0, false},
+		{"low remaining", rateLimitHeader(100, 4, 50*time.Second), 8 * time.Second, 10 * time.Second, false},
+		{"exhausted", rateLimitHeader(100, 0, 30*time.Second), 28 * time.Second, 30 * time.Second, false},
+		{"reset passed", rateLimitHeader(100, 0, -time.Minute), 0, 0, false},
+		{"exhausted until weeks away", rateLimitHeader(20000, 0, 20*24*time.Hour), 0, 0, true},
+		{"low remaining weeks away", rateLimitHeader(20000, 1500, 20*24*time.Hour), maxRetryDelay, maxRetryDelay, false},
+		{"missing limit", func() http.Header {
+			h := rateLimitHeader(0, 0, time.Minute)
+			h.Del("X-Ratelimit-Limit")
+			return h
+		}(), 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := &throttle{}
+			th.update(tt.header)
+			d, err := th.delay(time.Now())
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrRateLimited)) {
+				t.Errorf("delay() error = %v, want ErrRateLimited %v", err, tt.wantErr)
+			}
+			if d < tt.wantMin || d > tt.wantMax {
+				t.Errorf("delay() = %v, want between %v and %v", d, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestAutoThrottle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range rateLimitHeader(100, 1, time.Minute) {
+			w.Header()[k] = v
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// Without the option no delay is computed
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	if _, err := client.GetPhoto(context.Background(), "1"); err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+	if d := client.ThrottleDelay(); d != 0 {
+		t.Errorf("ThrottleDelay() = %v, want 0", d)
+	}
+
+	// With the option the next request would wait
+	client = NewClient("key", WithBaseURL(server.URL+"/"), WithAutoThrottle())
+	if _, err := client.GetPhoto(context.Background(), "1"); err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+	if d := client.ThrottleDelay(); d < 20*time.Second {
+		t.Errorf("ThrottleDelay() = %v, want about 30s", d)
+	}
+
+	// A pending throttle delay honors context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetPhoto(ctx, "1"); err != context.DeadlineExceeded {
+		t.Errorf("GetPhoto failed: expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestAutoThrottleExhaustedQuota(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		for k, v := range rateLimitHeader(20000, 0, 20*24*time.Hour) {
+			w.Header()[k] = v
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// Once the monthly quota is gone, the next request fails at once instead of waiting for the rollover
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithAutoThrottle())
+	if _, err := client.GetPhoto(context.Background(), "1"); err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+	start := time.Now()
+	if _, err := client.GetPhoto(context.Background(), "1"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("GetPhoto error = %v, want ErrRateLimited", err)
+	}
+	if time.Since(start) > time.Second || calls != 1 {
+		t.Errorf("exhausted quota waited %v and sent %d requests, want no wait and 1 request", time.Since(start), calls)
+	}
+}
+
+func TestRateLimitCallback(t *testing.T) {
+	remaining := 100
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "3" {
+			remaining--
+			for k, v := range rateLimitHeader(100, remaining, time.Hour) {
+				w.Header()[k] = v
+			}
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var seen []RateLimit
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithRateLimitCallback(func(rl RateLimit) {
+		seen = append(seen, rl)
+	}))
+	for page := 1; page <= 3; page++ {
+		if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{Page: Int(page)}); err != nil {
+			t.Fatalf("GetCurated failed: %v", err)
+		}
+	}
+
+	// The third response has no rate limit headers and is skipped
+	if len(seen) != 2 {
+		t.Fatalf("callback called %d times, want 2", len(seen))
+	}
+	if seen[0].Remaining != 99 || seen[1].Remaining != 98 || seen[1].Limit != 100 || seen[1].Reset.IsZero() {
+		t.Errorf("callback got %+v", seen)
+	}
+}
@@ -0,0 +1,19 @@
+package pexels
+
+import "context"
+
+// requestIDKey is the context key under which ContextWithRequestID stores a request ID.
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the given request ID.
+// When the client was created with WithRequestIDHeader, requests made with the returned context send the ID
+// in that header, tying the client's requests to the caller's logs and traces.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx by ContextWithRequestID, or false if there is none.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
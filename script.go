@@ -0,0 +1,85 @@
+package pexels
+
+import (
+	"context"
+	"sync"
+)
+
+// The functions in this file are conveniences for scripts and prototypes: they use DefaultClient and
+// context.Background(), so they cannot be cancelled or given a deadline. Servers should create their own
+// Client with NewClient and call its context-carrying methods instead.
+
+// DefaultClient is the client used by the package-level convenience functions.
+// When it is nil, it is created from the PEXELS_API_KEY environment variable on first use.
+var DefaultClient *Client
+
+// defaultClientMu guards the lazy initialization of DefaultClient.
+var defaultClientMu sync.Mutex
+
+// defaultClient returns DefaultClient, creating it from the environment if needed.
+func defaultClient() (*Client, error) {
+	defaultClientMu.Lock()
+	defer defaultClientMu.Unlock()
+	if DefaultClient == nil {
+		c, err := NewClientFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		DefaultClient = c
+	}
+	return DefaultClient, nil
+}
+
+// GetPhotos searches photos using DefaultClient. It is intended for scripts, not servers; see Client.GetPhotos.
+func GetPhotos(params *GetPhotosParams) (*GetPhotoResponse, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetPhotos(context.Background(), params)
+}
+
+// GetCurated retrieves curated photos using DefaultClient. It is intended for scripts, not servers; see Client.GetCurated.
+func GetCurated(params *GetCuratedPhotoParams) (*GetPhotoResponse, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetCurated(context.Background(), params)
+}
+
+// GetPhoto retrieves a photo using DefaultClient. It is intended for scripts, not servers; see Client.GetPhoto.
+func GetPhoto(id string) (*Photo, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetPhoto(context.Background(), id)
+}
+
+// GetVideos searches videos using DefaultClient. It is intended for scripts, not servers; see Client.GetVideos.
+func GetVideos(params *GetVideosParams) (*GetVideosResponse, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetVideos(context.Background(), params)
+}
+
+// GetPopularVideos retrieves popular videos using DefaultClient. It is intended for scripts, not servers; see Client.GetPopularVideos.
+func GetPopularVideos(params *GetPopularVideosParams) (*GetVideosResponse, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetPopularVideos(context.Background(), params)
+}
+
+// GetVideo retrieves a video using DefaultClient. It is intended for scripts, not servers; see Client.GetVideo.
+func GetVideo(id string) (*Video, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetVideo(context.Background(), id)
+}
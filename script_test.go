@@ -0,0 +1,35 @@
+package pexels
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDefaultClient(t *testing.T) {
+	defer func() { DefaultClient = nil }()
+
+	// Without a key in the environment the convenience functions fail
+	DefaultClient = nil
+	t.Setenv(DefaultAPIKeyEnv, "")
+	if _, err := GetPhoto("1"); err == nil {
+		t.Errorf("GetPhoto failed: expected an error without an API key")
+	}
+
+	// An explicitly set DefaultClient is used as is
+	var req *http.Request
+	DefaultClient = newStubClient(`{"id":1}`, &req)
+	photo, err := GetPhoto("1")
+	if err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+	if photo.ID != 1 || req == nil {
+		t.Errorf("GetPhoto failed: DefaultClient was not used")
+	}
+
+	// The client is created lazily from the environment
+	DefaultClient = nil
+	t.Setenv(DefaultAPIKeyEnv, "env-key")
+	if c, err := defaultClient(); err != nil || c.ApiKey != "env-key" {
+		t.Errorf("defaultClient() = %v, %v", c, err)
+	}
+}
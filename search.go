@@ -0,0 +1,257 @@
+package pexels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MediaType is the type of a media item, as reported in CollectionMedia.Type.
+type MediaType string
+
+// Media types returned by the Pexels API.
+const (
+	MediaTypePhoto MediaType = "Photo"
+	MediaTypeVideo MediaType = "Video"
+)
+
+// SearchItem is a single photo or video in a SearchResult.
+// Exactly one of Photo and Video is set, according to Type.
+type SearchItem struct {
+	Type  MediaType // Type of the media item
+	Photo *Photo    // Photo, if Type is MediaTypePhoto
+	Video *Video    // Video, if Type is MediaTypeVideo
+}
+
+// SearchResult represents the combined photo and video results of the Search function.
+type SearchResult struct {
+	Photos []Photo      // Photos matching the query
+	Videos []Video      // Videos matching the query
+	Items  []SearchItem // Photos and videos merged, grouped by type or interleaved depending on SearchInterleaved
+}
+
+// SearchOption configures a call to Search.
+type SearchOption func(*searchOptions)
+
+// searchOptions holds the settings applied by SearchOption functions.
+type searchOptions struct {
+	mediaType   MediaType
+	interleaved bool
+	page        *int
+	perPage     *int
+}
+
+// SearchMediaType restricts Search to photos or videos only.
+func SearchMediaType(t MediaType) SearchOption {
+	return func(o *searchOptions) {
+		o.mediaType = t
+	}
+}
+
+// SearchInterleaved makes Search alternate photos and videos in SearchResult.Items instead of listing all photos first.
+func SearchInterleaved() SearchOption {
+	return func(o *searchOptions) {
+		o.interleaved = true
+	}
+}
+
+// SearchPage sets the page number and number of results per page requested from each search.
+func SearchPage(page, perPage int) SearchOption {
+	return func(o *searchOptions) {
+		o.page = Int(page)
+		o.perPage = Int(perPage)
+	}
+}
+
+// Search searches photos and videos concurrently and merges the results.
+// It takes a context, a search query, and optional SearchOption values as input and returns a SearchResult and an error.
+// If one of the searches fails, the result still holds the items of the other one and the error describes the failure;
+// the result is nil only when every requested search failed.
+func (c *Client) Search(ctx context.Context, query string, opts ...SearchOption) (*SearchResult, error) {
+	o := searchOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.mediaType != "" && o.mediaType != MediaTypePhoto && o.mediaType != MediaTypeVideo {
+		return nil, fmt.Errorf("pexels: unknown media type %q", o.mediaType)
+	}
+
+	var (
+		wg                   sync.WaitGroup
+		photos               *GetPhotoResponse
+		videos               *GetVideosResponse
+		photosErr, videosErr error
+	)
+	if o.mediaType != MediaTypeVideo {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			photos, photosErr = c.GetPhotos(ctx, &GetPhotosParams{Query: query, Page: o.page, PerPage: o.perPage})
+		}()
+	}
+	if o.mediaType != MediaTypePhoto {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			videos, videosErr = c.GetVideos(ctx, &GetVideosParams{Query: query, Page: o.page, PerPage: o.perPage})
+		}()
+	}
+	wg.Wait()
+
+	if photos == nil && videos == nil {
+		return nil, errors.Join(photosErr, videosErr)
+	}
+	result := &SearchResult{}
+	if photos != nil {
+		result.Photos = photos.Photos
+	}
+	if videos != nil {
+		result.Videos = videos.Videos
+	}
+	result.Items = mergeSearchItems(result.Photos, result.Videos, o.interleaved)
+	if photosErr != nil {
+		photosErr = fmt.Errorf("photo search: %w", photosErr)
+	}
+	if videosErr != nil {
+		videosErr = fmt.Errorf("video search: %w", videosErr)
+	}
+	return result, errors.Join(photosErr, videosErr)
+}
+
+// mergeSearchItems merges photos and videos into a single list, either grouped by type or interleaved.
+func mergeSearchItems(photos []Photo, videos []Video, interleaved bool) []SearchItem {
+	items := make([]SearchItem, 0, len(photos)+len(videos))
+	if !interleaved {
+		for i := range photos {
+			items = append(items, SearchItem{Type: MediaTypePhoto, Photo: &photos[i]})
+		}
+		for i := range videos {
+			items = append(items, SearchItem{Type: MediaTypeVideo, Video: &videos[i]})
+		}
+		return items
+	}
+	for i := 0; i < len(photos) || i < len(videos); i++ {
+		if i < len(photos) {
+			items = append(items, SearchItem{Type: MediaTypePhoto, Photo: &photos[i]})
+		}
+		if i < len(videos) {
+			items = append(items, SearchItem{Type: MediaTypeVideo, Video: &videos[i]})
+		}
+	}
+	return items
+}
+
+// multiColorConcurrency is the maximum number of concurrent requests made by SearchPhotosMultiColor.
+const multiColorConcurrency = 3
+
+// SearchPhotosMultiColor searches photos once per color and merges the results.
+// It takes a context, a search query, a list of colors, and optional GetPhotosParams as input and returns a list of
+// photos and an error. The Color and Query fields of params are replaced for each request; the other fields apply to
+// every request. At most three requests run at a time to avoid hitting the rate limit. The photos are merged in the
+// order of colors with duplicates removed. A color whose search fails is skipped and its error, naming the color,
+// is joined into the returned error, so the photos of the other colors are still returned.
+func (c *Client) SearchPhotosMultiColor(ctx context.Context, query string, colors []string, params *GetPhotosParams) ([]Photo, error) {
+	if params == nil {
+		params = &GetPhotosParams{}
+	}
+	var (
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, multiColorConcurrency)
+		results = make([][]Photo, len(colors))
+		errs    = make([]error, len(colors))
+	)
+	for i, color := range colors {
+		wg.Add(1)
+		go func(i int, color string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			p := *params
+			p.Query = query
+			p.Color = color
+			resp, err := c.GetPhotos(ctx, &p)
+			if err != nil {
+				errs[i] = fmt.Errorf("color %q: %w", color, err)
+				return
+			}
+			results[i] = resp.Photos
+		}(i, color)
+	}
+	wg.Wait()
+
+	var photos []Photo
+	for _, r := range results {
+		photos = append(photos, r...)
+	}
+	return DedupePhotos(photos), errors.Join(errs...)
+}
+
+// photographerSearchPages is the maximum number of search pages GetPhotographerPhotos walks.
+const photographerSearchPages = 10
+
+// GetPhotographerPhotos retrieves photos by a photographer from the Pexels API, on a best-effort basis.
+// It takes a context, a photographer ID, and GetPhotosParams as input and returns a list of photos and an error.
+// The Pexels API has no endpoint listing a photographer's photos, so this walks up to ten pages of the search
+// described by params, which must have a Query, and keeps the photos whose PhotographerID matches. Photos by the
+// photographer that do not match the query, or only appear on later pages, are missed; a query such as the
+// photographer's name or a subject they are known for gives the best results. On error, the photos found so far
+// are returned with it.
+func (c *Client) GetPhotographerPhotos(ctx context.Context, photographerID int, params *GetPhotosParams) ([]Photo, error) {
+	if photographerID <= 0 {
+		return nil, fmt.Errorf("pexels: invalid photographer id %d", photographerID)
+	}
+	resp, err := c.GetPhotos(ctx, params)
+	var photos []Photo
+	for page := 1; ; page++ {
+		if err != nil {
+			return photos, err
+		}
+		for _, p := range resp.Photos {
+			if p.PhotographerID == photographerID {
+				photos = append(photos, p)
+			}
+		}
+		if page >= photographerSearchPages || len(resp.Photos) == 0 {
+			return photos, nil
+		}
+		var ok bool
+		if resp, ok, err = c.NextPhotoPage(ctx, resp); !ok {
+			return photos, nil
+		}
+	}
+}
+
+// GetPhotosRange retrieves the pages startPage to endPage, inclusive, of a photo search and merges them.
+// It takes a context, GetPhotosParams, and the first and last page numbers as input and returns a GetPhotoResponse
+// and an error. Pages are fetched one after the other with the PerPage of params, and the walk stops early once a
+// page comes back empty or without a next page. The merged response holds the photos of every page in order, Page
+// set to startPage, the PerPage and TotalResults reported by the API, the PrevPage link of the first page, and the
+// NextPage link of the last page fetched. On error, the response merged so far is returned with it, or nil if the
+// first page failed. params is not modified.
+func (c *Client) GetPhotosRange(ctx context.Context, params *GetPhotosParams, startPage, endPage int) (*GetPhotoResponse, error) {
+	if startPage < 1 || endPage < startPage {
+		return nil, fmt.Errorf("pexels: invalid page range %d to %d", startPage, endPage)
+	}
+	var merged *GetPhotoResponse
+	for page := startPage; page <= endPage; page++ {
+		p := *params
+		p.Page = Int(page)
+		resp, err := c.GetPhotos(ctx, &p)
+		if err != nil {
+			return merged, err
+		}
+		if merged == nil {
+			merged = resp
+		} else {
+			merged.Photos = append(merged.Photos, resp.Photos...)
+			merged.TotalResults = resp.TotalResults
+			merged.NextPage = resp.NextPage
+		}
+		if len(resp.Photos) == 0 || resp.NextPage == "" {
+			break
+		}
+	}
+	merged.Page = startPage
+	return merged, nil
+}
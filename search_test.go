@@ -0,0 +1,205 @@
+package pexels
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newSearchServer returns a server answering photo and video searches, failing the video search when failVideos is set.
+func newSearchServer(failVideos bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/videos/search"):
+			if failVideos {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(`{"videos":[{"id":10},{"id":11}]}`))
+		case strings.HasSuffix(r.URL.Path, "/search"):
+			w.Write([]byte(`{"photos":[{"id":1},{"id":2},{"id":3}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+// itemIDs returns the IDs of the items in order.
+func itemIDs(items []SearchItem) []int {
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		if item.Type == MediaTypePhoto {
+			ids = append(ids, item.Photo.ID)
+		} else {
+			ids = append(ids, item.Video.ID)
+		}
+	}
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	server := newSearchServer(false)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts []SearchOption
+		want []int
+	}{
+		{"grouped", nil, []int{1, 2, 3, 10, 11}},
+		{"interleaved", []SearchOption{SearchInterleaved()}, []int{1, 10, 2, 11, 3}},
+		{"photos only", []SearchOption{SearchMediaType(MediaTypePhoto)}, []int{1, 2, 3}},
+		{"videos only", []SearchOption{SearchMediaType(MediaTypeVideo)}, []int{10, 11}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := client.Search(ctx, "nature", tt.opts...)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			got := itemIDs(result.Items)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search items = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Search items = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchPartialFailure(t *testing.T) {
+	server := newSearchServer(true)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	result, err := client.Search(context.Background(), "nature")
+	if err == nil || !strings.Contains(err.Error(), "video search") {
+		t.Errorf("Search failed: expected a video search error, got %v", err)
+	}
+	if result == nil || len(result.Photos) != 3 || len(result.Videos) != 0 {
+		t.Fatalf("Search failed: expected partial photo results, got %+v", result)
+	}
+
+	// When the only requested search fails there is no result
+	result, err = client.Search(context.Background(), "nature", SearchMediaType(MediaTypeVideo))
+	if err == nil || result != nil {
+		t.Errorf("Search failed: expected only an error, got %+v, %v", result, err)
+	}
+}
+
+func TestSearchPhotosMultiColor(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		switch r.URL.Query().Get("color") {
+		case "red":
+			w.Write([]byte(`{"photos":[{"id":1},{"id":2}]}`))
+		case "blue":
+			w.Write([]byte(`{"photos":[{"id":2},{"id":3}]}`))
+		case "green":
+			w.Write([]byte(`{"photos":[{"id":4}]}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	photos, err := client.SearchPhotosMultiColor(context.Background(), "flowers", []string{"red", "blue", "black", "green", "white"}, nil)
+	if err == nil || !strings.Contains(err.Error(), `color "black"`) || !strings.Contains(err.Error(), `color "white"`) {
+		t.Errorf("SearchPhotosMultiColor error = %v, want black and white failures", err)
+	}
+	want := []int{1, 2, 3, 4}
+	if len(photos) != len(want) {
+		t.Fatalf("SearchPhotosMultiColor returned %d photos, want %d", len(photos), len(want))
+	}
+	for i, id := range want {
+		if photos[i].ID != id {
+			t.Errorf("photos[%d].ID = %d, want %d", i, photos[i].ID, id)
+		}
+	}
+	if maxInFlight > multiColorConcurrency {
+		t.Errorf("%d concurrent requests, want at most %d", maxInFlight, multiColorConcurrency)
+	}
+}
+
+func TestGetPhotographerPhotos(t *testing.T) {
+	// Photos are filtered by photographer across pages
+	var pages int32
+	filtered := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&pages, 1) == 1 {
+			w.Write([]byte(`{"photos":[{"id":1,"photographer_id":42},{"id":2,"photographer_id":7}],"next_page":"` + "http://" + r.Host + `/v1/search?page=2"}`))
+			return
+		}
+		w.Write([]byte(`{"photos":[{"id":3,"photographer_id":42}]}`))
+	}))
+	defer filtered.Close()
+
+	client := NewClient("key", WithBaseURL(filtered.URL+"/"))
+	photos, err := client.GetPhotographerPhotos(context.Background(), 42, &GetPhotosParams{Query: "nature"})
+	if err != nil || len(photos) != 2 || photos[0].ID != 1 || photos[1].ID != 3 {
+		t.Errorf("GetPhotographerPhotos = %+v, %v, want photos 1 and 3", photos, err)
+	}
+
+	// A missing query or ID is an error rather than a listing of everything
+	if _, err := client.GetPhotographerPhotos(context.Background(), 42, &GetPhotosParams{}); err == nil {
+		t.Errorf("GetPhotographerPhotos accepted an empty query")
+	}
+	if _, err := client.GetPhotographerPhotos(context.Background(), 0, &GetPhotosParams{Query: "nature"}); err == nil {
+		t.Errorf("GetPhotographerPhotos accepted photographer ID 0")
+	}
+}
+
+func TestGetPhotosRange(t *testing.T) {
+	server := newPagedServer(10, 3, 4)
+	defer server.Close()
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	params := &GetPhotosParams{Query: "nature", PerPage: Int(3)}
+
+	// Two pages merge into one response
+	resp, err := client.GetPhotosRange(ctx, params, 2, 3)
+	if err != nil {
+		t.Fatalf("GetPhotosRange failed: %v", err)
+	}
+	if len(resp.Photos) != 6 || resp.Photos[0].ID != 4 || resp.Photos[5].ID != 9 {
+		t.Errorf("GetPhotosRange(2, 3) returned %d photos %v", len(resp.Photos), resp.Photos)
+	}
+	if resp.Page != 2 || resp.PerPage != 3 || resp.TotalResults != 10 || !strings.Contains(resp.NextPage, "page=4") {
+		t.Errorf("GetPhotosRange(2, 3) = page %d, per page %d, total %d, next %q", resp.Page, resp.PerPage, resp.TotalResults, resp.NextPage)
+	}
+	if params.Page != nil {
+		t.Errorf("GetPhotosRange modified params.Page")
+	}
+
+	// A failing page returns the pages merged so far with the error
+	resp, err = client.GetPhotosRange(ctx, params, 3, 5)
+	if err == nil || resp == nil || len(resp.Photos) != 3 {
+		t.Errorf("GetPhotosRange(3, 5) = %v, %v, want page 3 and an error", resp, err)
+	}
+
+	// Invalid ranges fail without a request
+	for _, r := range [][2]int{{0, 2}, {3, 2}} {
+		if _, err := client.GetPhotosRange(ctx, params, r[0], r[1]); err == nil {
+			t.Errorf("GetPhotosRange(%d, %d) succeeded, want an error", r[0], r[1])
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package pexels
+
+import "sort"
+
+// SortPhotosByWidth sorts photos in place by width, ascending if asc is true and descending otherwise.
+// The sort is stable, so photos of equal width keep their relative order, e.g. the API's relevance order.
+func SortPhotosByWidth(photos []Photo, asc bool) {
+	sort.SliceStable(photos, func(i, j int) bool {
+		return less(photos[i].Width, photos[j].Width, asc)
+	})
+}
+
+// SortPhotosByHeight sorts photos in place by height, ascending if asc is true and descending otherwise.
+// The sort is stable, so photos of equal height keep their relative order.
+func SortPhotosByHeight(photos []Photo, asc bool) {
+	sort.SliceStable(photos, func(i, j int) bool {
+		return less(photos[i].Height, photos[j].Height, asc)
+	})
+}
+
+// SortVideosByDuration sorts videos in place by duration, ascending if asc is true and descending otherwise.
+// The sort is stable, so videos of equal duration keep their relative order.
+func SortVideosByDuration(videos []Video, asc bool) {
+	sort.SliceStable(videos, func(i, j int) bool {
+		return less(videos[i].Duration, videos[j].Duration, asc)
+	})
+}
+
+// less orders a before b in ascending order if asc is true, in descending order otherwise.
+func less(a, b int, asc bool) bool {
+	if asc {
+		return a < b
+	}
+	return a > b
+}
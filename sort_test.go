@@ -0,0 +1,43 @@
+package pexels
+
+import "testing"
+
+func TestSortPhotos(t *testing.T) {
+	photos := []Photo{{ID: 1, Width: 300, Height: 20}, {ID: 2, Width: 100, Height: 30}, {ID: 3, Width: 300, Height: 10}, {ID: 4, Width: 200, Height: 30}}
+	tests := []struct {
+		name string
+		sort func([]Photo)
+		want []int
+	}{
+		{"width asc", func(p []Photo) { SortPhotosByWidth(p, true) }, []int{2, 4, 1, 3}},
+		{"width desc", func(p []Photo) { SortPhotosByWidth(p, false) }, []int{1, 3, 4, 2}},
+		{"height asc", func(p []Photo) { SortPhotosByHeight(p, true) }, []int{3, 1, 2, 4}},
+		{"height desc", func(p []Photo) { SortPhotosByHeight(p, false) }, []int{2, 4, 1, 3}},
+	}
+	for _, tt := range tests {
+		sorted := append([]Photo(nil), photos...)
+		tt.sort(sorted)
+		for i, id := range tt.want {
+			if sorted[i].ID != id {
+				t.Errorf("%s: photo %d = %d, want %d", tt.name, i, sorted[i].ID, id)
+			}
+		}
+	}
+}
+
+func TestSortVideosByDuration(t *testing.T) {
+	videos := []Video{{ID: 1, Duration: 30}, {ID: 2, Duration: 10}, {ID: 3, Duration: 30}, {ID: 4, Duration: 20}}
+	SortVideosByDuration(videos, true)
+	for i, id := range []int{2, 4, 1, 3} {
+		if videos[i].ID != id {
+			t.Errorf("asc: video %d = %d, want %d", i, videos[i].ID, id)
+		}
+	}
+	SortVideosByDuration(videos, false)
+	for i, id := range []int{1, 3, 4, 2} {
+		if videos[i].ID != id {
+			t.Errorf("desc: video %d = %d, want %d", i, videos[i].ID, id)
+		}
+	}
+	SortVideosByDuration(nil, true)
+}
@@ -0,0 +1,64 @@
+package pexels
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Widths in pixels advertised for each PhotoSrc size in the srcset built by PhotoSrc.SrcSet.
+// Tiny is cropped to 280x200, Large is bounded by 940x650, and Large2X is Large at twice the pixel density.
+const (
+	SrcSetWidthTiny    = 280
+	SrcSetWidthLarge   = 940
+	SrcSetWidthLarge2X = 1880
+)
+
+// SrcSet returns an HTML srcset attribute value listing the tiny, large and large2x URLs with their widths,
+// e.g. "https://…?w=280 280w, https://…?w=940 940w, https://…?dpr=2&w=940 1880w". Empty URLs are skipped.
+// The small and medium sizes are scaled by height alone, so their width depends on the photo and they are not
+// included, nor are the cropped portrait and landscape sizes and the original.
+func (s PhotoSrc) SrcSet() string {
+	candidates := []struct {
+		url   string
+		width int
+	}{
+		{s.Tiny, SrcSetWidthTiny},
+		{s.Large, SrcSetWidthLarge},
+		{s.Large2X, SrcSetWidthLarge2X},
+	}
+	var parts []string
+	for _, c := range candidates {
+		if c.url != "" {
+			parts = append(parts, c.url+" "+strconv.Itoa(c.width)+"w")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sizeLadder returns the URLs of the uncropped sizes from the smallest to the largest.
+func (s PhotoSrc) sizeLadder() []string {
+	return []string{s.Tiny, s.Small, s.Medium, s.Large, s.Large2X, s.Original}
+}
+
+// Smallest returns the URL of the smallest size the API provided, from tiny up to original, or an empty string if
+// there is none. The cropped portrait and landscape sizes are not considered.
+func (s PhotoSrc) Smallest() string {
+	for _, url := range s.sizeLadder() {
+		if url != "" {
+			return url
+		}
+	}
+	return ""
+}
+
+// Largest returns the URL of the largest size the API provided, from original down to tiny, or an empty string if
+// there is none. The cropped portrait and landscape sizes are not considered.
+func (s PhotoSrc) Largest() string {
+	ladder := s.sizeLadder()
+	for i := len(ladder) - 1; i >= 0; i-- {
+		if ladder[i] != "" {
+			return ladder[i]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package pexels
+
+import "testing"
+
+func TestSrcSet(t *testing.T) {
+	tests := []struct {
+		name string
+		src  PhotoSrc
+		want string
+	}{
+		{"all sizes", PhotoSrc{Tiny: "t.jpg", Small: "s.jpg", Medium: "m.jpg", Large: "l.jpg", Large2X: "l2.jpg", Original: "o.jpg"}, "t.jpg 280w, l.jpg 940w, l2.jpg 1880w"},
+		{"large sizes", PhotoSrc{Large: "L", Large2X: "L2", Medium: "M"}, "L 940w, L2 1880w"},
+		{"height-scaled sizes only", PhotoSrc{Small: "s.jpg", Medium: "m.jpg"}, ""},
+		{"empty", PhotoSrc{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.src.SrcSet(); got != tt.want {
+			t.Errorf("%s: SrcSet() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSmallestAndLargest(t *testing.T) {
+	tests := []struct {
+		src                  PhotoSrc
+		wantSmall, wantLarge string
+	}{
+		{PhotoSrc{Original: "o", Large2X: "l2", Large: "l", Medium: "m", Small: "s", Tiny: "t", Portrait: "p", Landscape: "ls"}, "t", "o"},
+		{PhotoSrc{Large: "l", Medium: "m", Portrait: "p"}, "m", "l"},
+		{PhotoSrc{Small: "s"}, "s", "s"},
+		{PhotoSrc{Portrait: "p", Landscape: "ls"}, "", ""},
+		{PhotoSrc{}, "", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.src.Smallest(); got != tt.wantSmall {
+			t.Errorf("%+v.Smallest() = %q, want %q", tt.src, got, tt.wantSmall)
+		}
+		if got := tt.src.Largest(); got != tt.wantLarge {
+			t.Errorf("%+v.Largest() = %q, want %q", tt.src, got, tt.wantLarge)
+		}
+	}
+}
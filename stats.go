@@ -0,0 +1,70 @@
+package pexels
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// RequestStats is a snapshot of the request counters of a Client, as returned by Client.Stats.
+type RequestStats struct {
+	TotalRequests int64 // Number of HTTP requests sent to the API, including retries
+	Retries       int64 // Number of those requests that were retries of a failed attempt
+	RateLimitHits int64 // Number of responses with status 429 Too Many Requests
+	CacheHits     int64 // Number of calls served from the cache set with WithCache, without a request
+}
+
+// requestCounters holds the live counters behind RequestStats. It is safe for concurrent use.
+// A nil *requestCounters, as in a Client built as a struct literal rather than with NewClient, counts nothing.
+type requestCounters struct {
+	totalRequests atomic.Int64
+	retries       atomic.Int64
+	rateLimitHits atomic.Int64
+	cacheHits     atomic.Int64
+}
+
+// record counts one request attempt and its response.
+func (s *requestCounters) record(attempt int, res *http.Response) {
+	if s == nil {
+		return
+	}
+	s.totalRequests.Add(1)
+	if attempt > 1 {
+		s.retries.Add(1)
+	}
+	if res != nil && res.StatusCode == http.StatusTooManyRequests {
+		s.rateLimitHits.Add(1)
+	}
+}
+
+// cacheHit counts one call served from the cache.
+func (s *requestCounters) cacheHit() {
+	if s != nil {
+		s.cacheHits.Add(1)
+	}
+}
+
+// Stats returns the number of requests, retries, rate limit responses and cache hits seen by the client since it was
+// created or since the last call to ResetStats. It is safe to call while requests are in flight.
+// A Client not created with NewClient keeps no counters and always reports zero.
+func (c *Client) Stats() RequestStats {
+	if c.stats == nil {
+		return RequestStats{}
+	}
+	return RequestStats{
+		TotalRequests: c.stats.totalRequests.Load(),
+		Retries:       c.stats.retries.Load(),
+		RateLimitHits: c.stats.rateLimitHits.Load(),
+		CacheHits:     c.stats.cacheHits.Load(),
+	}
+}
+
+// ResetStats sets all request counters of the client back to zero.
+func (c *Client) ResetStats() {
+	if c.stats == nil {
+		return
+	}
+	c.stats.totalRequests.Store(0)
+	c.stats.retries.Store(0)
+	c.stats.rateLimitHits.Store(0)
+	c.stats.cacheHits.Store(0)
+}
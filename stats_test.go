@@ -0,0 +1,57 @@
+package pexels
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	server, _ := newFlakyServer(2, http.StatusTooManyRequests)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"), WithRetry(3, time.Millisecond))
+	if _, err := client.GetCurated(context.Background(), &GetCuratedPhotoParams{}); err != nil {
+		t.Fatalf("GetCurated failed: %v", err)
+	}
+	want := RequestStats{TotalRequests: 3, Retries: 2, RateLimitHits: 2}
+	if got := client.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	// Concurrent requests are all counted
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.GetCurated(context.Background(), &GetCuratedPhotoParams{})
+		}()
+	}
+	wg.Wait()
+	if got := client.Stats().TotalRequests; got != 13 {
+		t.Errorf("TotalRequests = %d, want 13", got)
+	}
+
+	client.ResetStats()
+	if got := client.Stats(); got != (RequestStats{}) {
+		t.Errorf("Stats() after ResetStats = %+v, want zero", got)
+	}
+}
+
+func TestStatsStructLiteralClient(t *testing.T) {
+	var req *http.Request
+	stub := newStubClient(`{"id":1}`, &req)
+
+	// A client built without NewClient has no counters but must still work
+	client := &Client{BaseURL: DefaultBaseURL, ApiKey: "key", HTTPClient: stub.HTTPClient, Version: DefaultVersion}
+	if _, err := client.GetPhoto(context.Background(), "1"); err != nil {
+		t.Fatalf("GetPhoto failed: %v", err)
+	}
+	if got := client.Stats(); got != (RequestStats{}) {
+		t.Errorf("Stats() = %+v, want zero", got)
+	}
+	client.ResetStats()
+}
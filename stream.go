@@ -0,0 +1,30 @@
+package pexels
+
+import "context"
+
+// StreamPhotos searches photos and streams every result, following the next page URLs in the background.
+// It takes a context and GetPhotosParams as input and returns a channel of photos and a channel of errors.
+// The photo channel is unbuffered, so pages are only fetched as fast as the caller consumes them.
+// The error channel delivers at most one error, either a request failure or the context error on cancellation,
+// after which both channels are closed; both are also closed once every page has been streamed.
+func (c *Client) StreamPhotos(ctx context.Context, params *GetPhotosParams) (<-chan Photo, <-chan error) {
+	photos := make(chan Photo)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(photos)
+		it := c.PhotosIter(ctx, params)
+		for it.Next() {
+			select {
+			case photos <- it.Photo():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errs <- err
+		}
+	}()
+	return photos, errs
+}
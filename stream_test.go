@@ -0,0 +1,67 @@
+package pexels
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStreamPhotos(t *testing.T) {
+	server := newPagedServer(7, 3)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	photos, errs := client.StreamPhotos(context.Background(), &GetPhotosParams{Query: "nature", PerPage: Int(3)})
+
+	var ids []int
+	for p := range photos {
+		ids = append(ids, p.ID)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("StreamPhotos failed: %v", err)
+	}
+	if len(ids) != 7 || ids[0] != 1 || ids[6] != 7 {
+		t.Errorf("StreamPhotos streamed %v, want 1..7", ids)
+	}
+}
+
+func TestStreamPhotosError(t *testing.T) {
+	server := newPagedServer(7, 3, 2)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	photos, errs := client.StreamPhotos(context.Background(), &GetPhotosParams{Query: "nature", PerPage: Int(3)})
+
+	count := 0
+	for range photos {
+		count++
+	}
+	var apiErr *APIError
+	if err := <-errs; !errors.As(err, &apiErr) {
+		t.Errorf("StreamPhotos failed: expected *APIError, got %v", err)
+	}
+	if count != 3 {
+		t.Errorf("StreamPhotos streamed %d photos before the error, want 3", count)
+	}
+	if _, ok := <-errs; ok {
+		t.Errorf("error channel delivered more than one error")
+	}
+}
+
+func TestStreamPhotosCancel(t *testing.T) {
+	server := newPagedServer(7, 3)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL+"/"))
+	ctx, cancel := context.WithCancel(context.Background())
+	photos, errs := client.StreamPhotos(ctx, &GetPhotosParams{Query: "nature", PerPage: Int(3)})
+
+	// Read one photo, then stop consuming and cancel
+	<-photos
+	cancel()
+	for range photos {
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("StreamPhotos failed: expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package pexels
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// validOrientations are the orientations accepted by the search endpoints.
+var validOrientations = []string{string(OrientationLandscape), string(OrientationPortrait), string(OrientationSquare)}
+
+// validSizes are the sizes accepted by the search endpoints.
+var validSizes = []string{string(SizeLarge), string(SizeMedium), string(SizeSmall)}
+
+// validSortOrders are the sort orders accepted by the collection media endpoint.
+var validSortOrders = []string{string(SortPopular), string(SortLatest)}
+
+// validColors are the named colors accepted by the photo search endpoint.
+var validColors = []string{"red", "orange", "yellow", "green", "turquoise", "blue", "violet", "pink", "brown", "black", "gray", "white"}
+
+// supportedLocales are the locales accepted by the search endpoints.
+var supportedLocales = []string{
+	"en-US", "pt-BR", "es-ES", "ca-ES", "de-DE", "it-IT", "fr-FR", "sv-SE", "id-ID", "pl-PL",
+	"ja-JP", "zh-TW", "zh-CN", "ko-KR", "th-TH", "nl-NL", "hu-HU", "vi-VN", "cs-CZ", "da-DK",
+	"fi-FI", "uk-UA", "el-GR", "ro-RO", "nb-NO", "sk-SK", "tr-TR", "ru-RU",
+}
+
+// SupportedLocales returns the locales documented by the Pexels API for the Locale search parameter,
+// e.g. to populate a selection list. The returned slice is a copy and may be modified.
+func SupportedLocales() []string {
+	return append([]string(nil), supportedLocales...)
+}
+
+// hexColorPattern matches a hexadecimal color code in the #rrggbb form.
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// validateEnum returns an error naming the field if value is not empty and not one of allowed.
+func validateEnum(field, value string, allowed []string) error {
+	if value == "" || contains(allowed, value) {
+		return nil
+	}
+	return fmt.Errorf("%s field must be one of %s, got %q.", field, strings.Join(allowed, ", "), value)
+}
+
+// normalizeColor lowercases a color and adds the leading # to a bare six digit hex code, so that "Red", "FF0000"
+// and "#ff0000" are all accepted. Other values are returned lowercased for validateColor to check.
+func normalizeColor(color string) string {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if len(color) == 6 && hexColorPattern.MatchString("#"+color) {
+		return "#" + color
+	}
+	return color
+}
+
+// validateColor returns an error if color is not empty, not a named color, and not a #rrggbb hex code.
+func validateColor(color string) error {
+	if color == "" || contains(validColors, color) || hexColorPattern.MatchString(color) {
+		return nil
+	}
+	return fmt.Errorf("Color field must be one of %s or a #rrggbb hex code, got %q.", strings.Join(validColors, ", "), color)
+}
+
+// validateID returns an error wrapping ErrInvalidID if id is empty or not a decimal number.
+// kind names the media type in the message.
+func validateID(kind, id string) error {
+	if id != "" && isDigits(id) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s id must be a non-empty number, got %q", ErrInvalidID, kind, id)
+}
+
+// validate returns an error if a size or duration bound is negative, or if MinDuration exceeds MaxDuration
+// while both are set. Zero values mean unspecified.
+func (p *GetPopularVideosParams) validate() error {
+	for _, f := range []struct {
+		name  string
+		value int
+	}{
+		{"MinWidth", p.MinWidth},
+		{"MinHeight", p.MinHeight},
+		{"MinDuration", p.MinDuration},
+		{"MaxDuration", p.MaxDuration},
+	} {
+		if f.value < 0 {
+			return fmt.Errorf("%s field cannot be negative, got %d.", f.name, f.value)
+		}
+	}
+	if p.MinDuration > 0 && p.MaxDuration > 0 && p.MinDuration > p.MaxDuration {
+		return fmt.Errorf("MinDuration field cannot be greater than MaxDuration, got %d > %d.", p.MinDuration, p.MaxDuration)
+	}
+	return nil
+}
+
+// validateLocale returns an error if locale is not empty and not one of SupportedLocales,
+// unless the client was created with WithoutLocaleValidation.
+func (c *Client) validateLocale(locale string) error {
+	if c.noLocaleCheck {
+		return nil
+	}
+	return validateEnum("Locale", locale, supportedLocales)
+}
+
+// contains reports whether value is one of values.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the optional enum fields of GetPhotosParams before a request is made.
+func (p *GetPhotosParams) validate() error {
+	if err := validateOrientationAndSize(p.Orientation, p.Size); err != nil {
+		return err
+	}
+	return validateColor(p.Color)
+}
+
+// validate checks the optional enum fields of GetVideosParams before a request is made.
+func (p *GetVideosParams) validate() error {
+	return validateOrientationAndSize(p.Orientation, p.Size)
+}
+
+// validate checks the Sort field of GetCollectionMediaParams before a request is made.
+func (p *GetCollectionMediaParams) validate() error {
+	return validateEnum("Sort", string(p.Sort), validSortOrders)
+}
+
+// validateOrientationAndSize checks the Orientation and Size fields shared by the photo and video searches.
+func validateOrientationAndSize(orientation Orientation, size Size) error {
+	if err := validateEnum("Orientation", string(orientation), validOrientations); err != nil {
+		return err
+	}
+	return validateEnum("Size", string(size), validSizes)
+}
+
+// normalizeOrientationAndSize lowercases the Orientation and Size fields shared by the photo and video searches,
+// since the API only matches lowercase values and answers "Medium" with no results rather than an error.
+func normalizeOrientationAndSize(orientation *Orientation, size *Size) {
+	*orientation = Orientation(normalizeEnum(string(*orientation)))
+	*size = Size(normalizeEnum(string(*size)))
+}
+
+// normalizeEnum trims and lowercases an enum value, the form in which the API matches it.
+func normalizeEnum(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// normalize lowercases the enum fields of GetPhotosParams before they are validated and encoded.
+func (p *GetPhotosParams) normalize() {
+	normalizeOrientationAndSize(&p.Orientation, &p.Size)
+	p.Color = normalizeColor(p.Color)
+}
+
+// normalize lowercases the enum fields of GetVideosParams before they are validated and encoded.
+func (p *GetVideosParams) normalize() {
+	normalizeOrientationAndSize(&p.Orientation, &p.Size)
+}
+
+// applySearchDefaults fills in an empty orientation and size with the defaults set by WithDefaultOrientation and
+// WithDefaultSize.
+func (c *Client) applySearchDefaults(orientation *Orientation, size *Size) {
+	if *orientation == "" {
+		*orientation = c.defaultOrient
+	}
+	if *size == "" {
+		*size = c.defaultSize
+	}
+}
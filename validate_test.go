@@ -0,0 +1,311 @@
+package pexels
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetPhotosValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  GetPhotosParams
+		wantErr bool
+	}{
+		{"empty optional fields", GetPhotosParams{Query: "nature"}, false},
+		{"valid enums", GetPhotosParams{Query: "nature", Orientation: "square", Size: "large", Color: "turquoise"}, false},
+		{"hex color", GetPhotosParams{Query: "nature", Color: "#00FF7f"}, false},
+		{"invalid orientation", GetPhotosParams{Query: "nature", Orientation: "wide"}, true},
+		{"invalid size", GetPhotosParams{Query: "nature", Size: "huge"}, true},
+		{"invalid color", GetPhotosParams{Query: "nature", Color: "magenta"}, true},
+		{"short hex color", GetPhotosParams{Query: "nature", Color: "#fff"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			client := newStubClient(`{}`, &req)
+			_, err := client.GetPhotos(context.Background(), &tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPhotos() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && req != nil {
+				t.Errorf("GetPhotos() sent a request despite invalid params")
+			}
+		})
+	}
+}
+
+func TestGetByIDValidation(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr bool
+	}{
+		{"2014422", false},
+		{"", true},
+		{"abc", true},
+		{"12/34", true},
+		{" 12", true},
+	}
+	for _, tt := range tests {
+		var req *http.Request
+		client := newStubClient(`{}`, &req)
+		_, photoErr := client.GetPhoto(context.Background(), tt.id)
+		_, videoErr := client.GetVideo(context.Background(), tt.id)
+		for name, err := range map[string]error{"GetPhoto": photoErr, "GetVideo": videoErr} {
+			if tt.wantErr != errors.Is(err, ErrInvalidID) {
+				t.Errorf("%s(%q) error = %v, wantErr %v", name, tt.id, err, tt.wantErr)
+			}
+		}
+		if tt.wantErr && req != nil {
+			t.Errorf("GetPhoto(%q) sent a request despite an invalid ID", tt.id)
+		}
+	}
+}
+
+func TestLocaleValidation(t *testing.T) {
+	tests := []struct {
+		locale  string
+		opts    []Option
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"pt-BR", nil, false},
+		{"en-us", nil, true},
+		{"xx-XX", nil, true},
+		{"xx-XX", []Option{WithoutLocaleValidation()}, false},
+	}
+	for _, tt := range tests {
+		var req *http.Request
+		client := newStubClient(`{}`, &req, tt.opts...)
+		_, photoErr := client.GetPhotos(context.Background(), &GetPhotosParams{Query: "nature", Locale: tt.locale})
+		_, videoErr := client.GetVideos(context.Background(), &GetVideosParams{Query: "nature", Locale: tt.locale})
+		for name, err := range map[string]error{"GetPhotos": photoErr, "GetVideos": videoErr} {
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s(Locale: %q) error = %v, wantErr %v", name, tt.locale, err, tt.wantErr)
+			}
+		}
+	}
+}
+
+func TestSupportedLocales(t *testing.T) {
+	locales := SupportedLocales()
+	if !contains(locales, "en-US") || !contains(locales, "ja-JP") {
+		t.Errorf("SupportedLocales() = %v, missing en-US or ja-JP", locales)
+	}
+	locales[0] = "changed"
+	if SupportedLocales()[0] == "changed" {
+		t.Errorf("SupportedLocales() returned the internal slice")
+	}
+}
+
+func TestGetPopularVideosValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  GetPopularVideosParams
+		wantErr bool
+	}{
+		{"unspecified", GetPopularVideosParams{}, false},
+		{"min only", GetPopularVideosParams{MinDuration: 30}, false},
+		{"max only", GetPopularVideosParams{MaxDuration: 30}, false},
+		{"equal bounds", GetPopularVideosParams{MinDuration: 30, MaxDuration: 30}, false},
+		{"ordered bounds", GetPopularVideosParams{MinDuration: 29, MaxDuration: 30}, false},
+		{"reversed bounds", GetPopularVideosParams{MinDuration: 31, MaxDuration: 30}, true},
+		{"zero min width", GetPopularVideosParams{MinWidth: 0, MinHeight: 1}, false},
+		{"negative min width", GetPopularVideosParams{MinWidth: -1}, true},
+		{"negative min height", GetPopularVideosParams{MinHeight: -1}, true},
+		{"negative duration", GetPopularVideosParams{MinDuration: -5}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			client := newStubClient(`{}`, &req)
+			_, err := client.GetPopularVideos(context.Background(), &tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPopularVideos() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && req != nil {
+				t.Errorf("GetPopularVideos() sent a request despite invalid params")
+			}
+		})
+	}
+}
+
+func TestGetVideosValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  GetVideosParams
+		wantErr bool
+	}{
+		{"empty optional fields", GetVideosParams{Query: "nature"}, false},
+		{"valid enums", GetVideosParams{Query: "nature", Orientation: OrientationPortrait, Size: SizeSmall}, false},
+		{"invalid orientation", GetVideosParams{Query: "nature", Orientation: "wide"}, true},
+		{"invalid size", GetVideosParams{Query: "nature", Size: "huge"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			client := newStubClient(`{}`, &req)
+			_, err := client.GetVideos(context.Background(), &tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetVideos() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && req != nil {
+				t.Errorf("GetVideos() sent a request despite invalid params")
+			}
+		})
+	}
+}
+
+func TestGetPhotosColorNormalization(t *testing.T) {
+	tests := []struct {
+		color   string
+		want    string
+		wantErr bool
+	}{
+		{"red", "red", false},
+		{"Red", "red", false},
+		{"#ff0000", "#ff0000", false},
+		{"#FF0000", "#ff0000", false},
+		{"ff0000", "#ff0000", false},
+		{"FF00aa", "#ff00aa", false},
+		{"#ff00", "", true},
+		{"ff00zz", "", true},
+		{"#ff00000", "", true},
+		{"crimson", "", true},
+	}
+	for _, tt := range tests {
+		var req *http.Request
+		client := newStubClient(`{}`, &req)
+		_, err := client.GetPhotos(context.Background(), &GetPhotosParams{Query: "nature", Color: tt.color})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("GetPhotos(Color: %q) error = %v, wantErr %v", tt.color, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			if !strings.Contains(err.Error(), "Color field") {
+				t.Errorf("GetPhotos(Color: %q) error = %v, want it to name the Color field", tt.color, err)
+			}
+			continue
+		}
+		if got := req.URL.Query().Get("color"); got != tt.want {
+			t.Errorf("GetPhotos(Color: %q) sent color=%q, want %q", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultOrientationAndSize(t *testing.T) {
+	ctx := context.Background()
+	var req *http.Request
+	client := newStubClient(`{}`, &req, WithDefaultOrientation(OrientationPortrait), WithDefaultSize(SizeLarge))
+
+	// Blank fields take the defaults
+	if _, err := client.GetVideos(ctx, &GetVideosParams{Query: "sea"}); err != nil {
+		t.Fatalf("GetVideos failed: %v", err)
+	}
+	if q := req.URL.Query(); q.Get("orientation") != "portrait" || q.Get("size") != "large" {
+		t.Errorf("GetVideos sent orientation=%q size=%q, want the defaults", q.Get("orientation"), q.Get("size"))
+	}
+
+	// Per-call values win
+	if _, err := client.GetPhotos(ctx, &GetPhotosParams{Query: "sea", Orientation: OrientationSquare}); err != nil {
+		t.Fatalf("GetPhotos failed: %v", err)
+	}
+	if q := req.URL.Query(); q.Get("orientation") != "square" || q.Get("size") != "large" {
+		t.Errorf("GetPhotos sent orientation=%q size=%q, want square and large", q.Get("orientation"), q.Get("size"))
+	}
+
+	// Without the options nothing is added
+	if _, err := newStubClient(`{}`, &req).GetPhotos(ctx, &GetPhotosParams{Query: "sea"}); err != nil {
+		t.Fatalf("GetPhotos failed: %v", err)
+	}
+	if q := req.URL.Query(); q.Has("orientation") || q.Has("size") {
+		t.Errorf("GetPhotos sent %s without defaults", req.URL.RawQuery)
+	}
+}
+
+func TestDefaultOrientationAndSizeCase(t *testing.T) {
+	var req *http.Request
+	client := newStubClient(`{}`, &req, WithDefaultOrientation("Portrait"), WithDefaultSize(" LARGE"))
+	if _, err := client.GetVideos(context.Background(), &GetVideosParams{Query: "sea"}); err != nil {
+		t.Fatalf("GetVideos failed: %v", err)
+	}
+	if got, want := req.URL.RawQuery, "orientation=portrait&page=1&per_page=5&query=sea&size=large"; got != want {
+		t.Errorf("query = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultOrientationInvalid(t *testing.T) {
+	for name, option := range map[string]func(){
+		"WithDefaultOrientation": func() { WithDefaultOrientation("diagonal") },
+		"WithDefaultSize":        func() { WithDefaultSize("huge") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s accepted an invalid value", name)
+				}
+			}()
+			option()
+		}()
+	}
+}
+
+func TestGetCollectionSortValidation(t *testing.T) {
+	ctx := context.Background()
+	var req *http.Request
+	client := newStubClient(`{}`, &req)
+
+	if _, err := client.GetCollection(ctx, &GetCollectionMediaParams{Sort: "newest"}, "abc"); err == nil || !strings.Contains(err.Error(), "Sort field") {
+		t.Errorf("GetCollection with an invalid sort = %v, want a Sort field error", err)
+	}
+	if req != nil {
+		t.Errorf("GetCollection with an invalid sort sent a request")
+	}
+
+	for _, sort := range []SortOrder{SortPopular, SortLatest, ""} {
+		if _, err := client.GetCollection(ctx, &GetCollectionMediaParams{Sort: sort}, "abc"); err != nil {
+			t.Fatalf("GetCollection(%q) failed: %v", sort, err)
+		}
+		if got := req.URL.Query().Get("sort"); got != string(sort) {
+			t.Errorf("GetCollection(%q) sent sort=%q", sort, got)
+		}
+	}
+}
+
+func TestSearchCaseNormalization(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(c *Client) error
+		want string
+	}{
+		{"GetPhotos", func(c *Client) error {
+			_, err := c.GetPhotos(ctx, &GetPhotosParams{Query: "sea", Orientation: "Landscape", Size: "MEDIUM", Color: "Red"})
+			return err
+		}, "color=red&orientation=landscape&page=1&per_page=5&query=sea&size=medium"},
+		{"GetVideos", func(c *Client) error {
+			_, err := c.GetVideos(ctx, &GetVideosParams{Query: "sea", Orientation: " Portrait", Size: "Large"})
+			return err
+		}, "orientation=portrait&page=1&per_page=5&query=sea&size=large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			client := newStubClient(`{}`, &req)
+			if err := tt.call(client); err != nil {
+				t.Fatalf("%s rejected mixed-case values: %v", tt.name, err)
+			}
+			if got := req.URL.RawQuery; got != tt.want {
+				t.Errorf("query = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Values that are still invalid once lowercased are rejected
+	var req *http.Request
+	if _, err := newStubClient(`{}`, &req).GetVideos(ctx, &GetVideosParams{Query: "sea", Size: "Huge"}); err == nil || req != nil {
+		t.Errorf("GetVideos accepted size %q", "Huge")
+	}
+}
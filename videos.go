@@ -2,8 +2,10 @@ package pexels
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 // VideoFile represents a file of a video in the Pexels API.
@@ -31,14 +33,91 @@ type Video struct {
 	Height        int            `json:"height"`         // Height of the video in pixels
 	URL           string         `json:"url"`            // URL to the video
 	Image         string         `json:"image"`          // URL to the video's image
-	FullRes       any            `json:"full_res"`       // Full resolution of the video
-	Tags          []any          `json:"tags"`           // Tags of the video
+	FullRes       *string        `json:"full_res"`       // URL to the full resolution of the video, nil if unavailable
+	Tags          []string       `json:"tags"`           // Tags of the video
 	Duration      int            `json:"duration"`       // Duration of the video in seconds
 	User          User           `json:"user"`           // User who uploaded the video
 	VideoFiles    []VideoFile    `json:"video_files"`    // Files of the video
 	VideoPictures []VideoPicture `json:"video_pictures"` // Pictures of the video
 }
 
+// ThumbnailAt returns the URL of the video picture with sequence number nr, or false if there is none.
+func (v Video) ThumbnailAt(nr int) (string, bool) {
+	for _, p := range v.VideoPictures {
+		if p.Nr == nr {
+			return p.Picture, true
+		}
+	}
+	return "", false
+}
+
+// FirstThumbnail returns the URL of the first video picture, falling back to Image when there are no pictures.
+// It returns an empty string when neither is available.
+func (v Video) FirstThumbnail() string {
+	if len(v.VideoPictures) > 0 {
+		return v.VideoPictures[0].Picture
+	}
+	return v.Image
+}
+
+// DurationString returns the duration of the video for display, as m:ss or h:mm:ss, e.g. "1:05" or "1:02:03".
+func (v Video) DurationString() string {
+	return formatDuration(v.Duration)
+}
+
+// formatDuration formats a number of seconds as m:ss, or h:mm:ss from one hour on. Negative values count as zero.
+func formatDuration(seconds int) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	h, m, s := seconds/3600, seconds/60%60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// UnmarshalJSON decodes a video, tolerating a null or absent full_res and tags as well as non-string values in them.
+func (v *Video) UnmarshalJSON(data []byte) error {
+	type alias Video
+	aux := struct {
+		*alias
+		FullRes json.RawMessage `json:"full_res"`
+		Tags    json.RawMessage `json:"tags"`
+	}{alias: (*alias)(v)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	v.FullRes = decodeFullRes(aux.FullRes)
+	v.Tags = decodeTags(aux.Tags)
+	return nil
+}
+
+// decodeFullRes decodes a full_res value, returning nil unless it is a string.
+func decodeFullRes(raw json.RawMessage) *string {
+	var s *string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil
+	}
+	return s
+}
+
+// decodeTags decodes a tags value, returning nil unless it is an array and dropping any non-string entries.
+func decodeTags(raw json.RawMessage) []string {
+	var values []json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil || values == nil {
+		return nil
+	}
+	tags := make([]string, 0, len(values))
+	for _, v := range values {
+		var tag string
+		if err := json.Unmarshal(v, &tag); err == nil {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // GetVideosResponse represents the response from the GetVideos function.
 type GetVideosResponse struct {
 	Page         int     `json:"page"`          // Current page number
@@ -46,42 +125,74 @@ type GetVideosResponse struct {
 	TotalResults int     `json:"total_results"` // Total number of results for the query
 	URL          string  `json:"url"`           // URL to the video
 	Videos       []Video `json:"videos"`        // List of videos matching the query
+	NextPage     string  `json:"next_page"`     // URL to the next page of results
+	PrevPage     string  `json:"prev_page"`     // URL to the previous page of results
+}
+
+// FilterByAspectRatio returns the videos whose width divided by height lies between min and max, inclusive.
+// A max of zero means no upper bound. Videos with an unknown height are skipped. It makes no network calls.
+func (r *GetVideosResponse) FilterByAspectRatio(min, max float64) []Video {
+	var videos []Video
+	for _, v := range r.Videos {
+		ratio := v.AspectRatio()
+		if ratio == 0 || ratio < min || (max > 0 && ratio > max) {
+			continue
+		}
+		videos = append(videos, v)
+	}
+	return videos
+}
+
+// FilterByDuration returns the videos whose duration in seconds lies between min and max, inclusive.
+// A max of zero means no upper bound. It makes no network calls.
+func (r *GetVideosResponse) FilterByDuration(min, max int) []Video {
+	var videos []Video
+	for _, v := range r.Videos {
+		if v.Duration < min || (max > 0 && v.Duration > max) {
+			continue
+		}
+		videos = append(videos, v)
+	}
+	return videos
 }
 
 // GetVideosParams represents the parameters for the GetVideos function.
 type GetVideosParams struct {
-	Query       string `url:"query"`       // Search query for videos
-	Orientation string `url:"orientation"` // Desired orientation of videos (e.g., landscape, portrait)
-	Size        string `url:"size"`        // Desired size of videos (e.g., small, medium, large)
-	Locale      string `url:"locale"`      // Locale for the search query
-	Page        int    `url:"page"`        // Page number for paginated results
-	PerPage     int    `url:"per_page"`    // Number of results per page
+	Query       string      `url:"query,omitempty"`       // Search query for videos
+	Orientation Orientation `url:"orientation,omitempty"` // Desired orientation of videos (e.g., landscape, portrait)
+	Size        Size        `url:"size,omitempty"`        // Desired size of videos (e.g., small, medium, large)
+	Locale      string      `url:"locale,omitempty"`      // Locale for the search query, one of SupportedLocales
+	Page        *int        `url:"page,omitempty"`        // Page number for paginated results, 1 when nil
+	PerPage     *int        `url:"per_page,omitempty"`    // Number of results per page, the endpoint default when nil, clamped to MaxPerPage
+	Extra       url.Values  // Additional query parameters; explicit fields take precedence on key collisions
 }
 
 // GetPopularVideosParams represents the parameters for the GetPopularVideos function.
 type GetPopularVideosParams struct {
-	MinWidth    int `url:"min_width"`    // Minimum width of the videos
-	MinHeight   int `url:"min_height"`   // Minimum height of the videos
-	MinDuration int `url:"min_duration"` // Minimum duration of the videos
-	MaxDuration int `url:"max_duration"` // Maximum duration of the videos
-	Page        int `url:"page"`         // Page number for paginated results
-	PerPage     int `url:"per_page"`     // Number of results per page
+	MinWidth    int        `url:"min_width,omitempty"`    // Minimum width of the videos
+	MinHeight   int        `url:"min_height,omitempty"`   // Minimum height of the videos
+	MinDuration int        `url:"min_duration,omitempty"` // Minimum duration of the videos
+	MaxDuration int        `url:"max_duration,omitempty"` // Maximum duration of the videos
+	Page        *int       `url:"page,omitempty"`         // Page number for paginated results, 1 when nil
+	PerPage     *int       `url:"per_page,omitempty"`     // Number of results per page, the endpoint default when nil, clamped to MaxPerPage
+	Extra       url.Values // Additional query parameters; explicit fields take precedence on key collisions
+}
+
+// videosURL builds the URL of a video endpoint.
+// Video endpoints live under BaseURL/videos/ rather than under the API version used by photos and collections.
+func (c *Client) videosURL(path string, query url.Values) string {
+	return c.buildURL("videos/"+path, query)
 }
 
 // GetVideo retrieves a video from the Pexels API.
 // It takes a context and an ID as input and returns a Video and an error.
-// The ID is the unique identifier for the video.
+// The ID is the unique identifier for the video; an empty or non-numeric ID returns an error wrapping ErrInvalidID without a request.
 // The Video contains the ID, width, height, URL, image URL, full resolution, tags, duration, user, video files, and video pictures of the video.
 func (c *Client) GetVideo(ctx context.Context, id string) (*Video, error) {
-	url := fmt.Sprintf("%s/videos/videos/%s", c.BaseURL, id)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := c.newVideoRequest(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.ApiKey)
 
 	var resp Video = Video{}
 	err = c.sendRequest(ctx, req, &resp)
@@ -91,26 +202,47 @@ func (c *Client) GetVideo(ctx context.Context, id string) (*Video, error) {
 	return &resp, nil
 }
 
+// GetVideoRaw is like GetVideo but also returns the JSON body of the response as received, for debugging or to read
+// fields that Video does not model yet. The client keeps no reference to the body; it stays in memory only as long
+// as the caller holds the returned RawMessage.
+func (c *Client) GetVideoRaw(ctx context.Context, id string) (*Video, json.RawMessage, error) {
+	req, err := c.newVideoRequest(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp := Video{}
+	raw := rawCapture{v: &resp, strict: c.strictDecoding}
+	if err := c.sendRequest(ctx, req, &raw); err != nil {
+		return nil, nil, err
+	}
+	return &resp, raw.raw, nil
+}
+
+// newVideoRequest validates a video ID and creates the request retrieving that video.
+func (c *Client) newVideoRequest(ctx context.Context, id string) (*http.Request, error) {
+	if err := validateID("video", id); err != nil {
+		return nil, err
+	}
+	return c.newRequest(ctx, http.MethodGet, c.videosURL("videos/"+id, nil))
+}
+
 // GetPopularVideos retrieves a list of popular videos from the Pexels API.
 // It takes a context and GetPopularVideosParams as input and returns a GetVideosResponse and an error.
 // The GetPopularVideosParams specify the minimum width, minimum height, minimum duration, maximum duration, page, and per page parameters.
 // The GetVideosResponse contains the current page number, the number of results per page, the total number of results, a URL to the video, and a list of videos matching the query.
 func (c *Client) GetPopularVideos(ctx context.Context, params *GetPopularVideosParams) (*GetVideosResponse, error) {
-	if params.Page == 0 {
-		params.Page = 1
+	if err := c.applyPaging(&params.Page, &params.PerPage, 2); err != nil {
+		return nil, err
 	}
-	if params.PerPage == 0 {
-		params.PerPage = 2
+	if err := params.validate(); err != nil {
+		return nil, err
 	}
-	url := fmt.Sprintf("%svideos/popular?%s", c.BaseURL, c.structToURLValues(*params).Encode())
-	req, err := http.NewRequest("GET", url, nil)
+	url := c.videosURL("popular", c.structToURLValues(*params))
+	req, err := c.newRequest(ctx, http.MethodGet, url)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.ApiKey)
 
 	var resp GetVideosResponse = GetVideosResponse{}
 	err = c.sendRequest(ctx, req, &resp)
@@ -124,24 +256,30 @@ func (c *Client) GetPopularVideos(ctx context.Context, params *GetPopularVideosP
 // It takes a context and GetVideosParams as input and returns a GetVideosResponse and an error.
 // The GetVideosParams specify the search query, orientation, size, locale, page, and per page parameters.
 // The GetVideosResponse contains the current page number, the number of results per page, the total number of results, a URL to the video, and a list of videos matching the query.
+// An empty query is an error unless the client was created with WithQueryFallback, in which case popular videos are returned.
 func (c *Client) GetVideos(ctx context.Context, params *GetVideosParams) (*GetVideosResponse, error) {
-	if params.Page == 0 {
-		params.Page = 1
-	}
-	if params.PerPage == 0 {
-		params.PerPage = 5
+	if err := c.applyPaging(&params.Page, &params.PerPage, 5); err != nil {
+		return nil, err
 	}
 	if params.Query == "" {
+		if c.queryFallback {
+			return c.GetPopularVideos(ctx, &GetPopularVideosParams{Page: params.Page, PerPage: params.PerPage, Extra: params.Extra})
+		}
 		return nil, fmt.Errorf("Query field cannot be empty.")
 	}
-	url := fmt.Sprintf("%s/videos/search?%s", c.BaseURL, c.structToURLValues(*params).Encode())
-	req, err := http.NewRequest("GET", url, nil)
+	c.applySearchDefaults(&params.Orientation, &params.Size)
+	params.normalize()
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.validateLocale(params.Locale); err != nil {
+		return nil, err
+	}
+	url := c.videosURL("search", c.structToURLValues(*params))
+	req, err := c.newRequest(ctx, http.MethodGet, url)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", c.ApiKey)
 
 	var resp GetVideosResponse = GetVideosResponse{}
 	err = c.sendRequest(ctx, req, &resp)
@@ -2,18 +2,19 @@ package pexels
 
 import (
 	"context"
-	"os"
+	"encoding/json"
+	"net/http"
 	"testing"
 )
 
 func TestGetPopularVideos(t *testing.T) {
 	// Initialize a new Pexels API client
-	client := NewClient(os.Getenv("PEXELS_API_KEY"))
+	client := newLiveClient(t)
 
 	// Set up the parameters for the GetPopularVideos function
 	params := &GetPopularVideosParams{
-		Page:    1,
-		PerPage: 10,
+		Page:    Int(1),
+		PerPage: Int(10),
 	}
 
 	// Call the GetPopularVideos function
@@ -33,7 +34,7 @@ func TestGetPopularVideos(t *testing.T) {
 
 func TestGetVideos(t *testing.T) {
 	// Initialize a new Pexels API client
-	client := NewClient(os.Getenv("PEXELS_API_KEY"))
+	client := newLiveClient(t)
 
 	// Set up the parameters for the GetVideos function
 	params := &GetVideosParams{
@@ -41,8 +42,8 @@ func TestGetVideos(t *testing.T) {
 		Orientation: "landscape",
 		Size:        "medium",
 		Locale:      "en-US",
-		Page:        1,
-		PerPage:     10,
+		Page:        Int(1),
+		PerPage:     Int(10),
 	}
 
 	// Call the GetVideos function
@@ -62,7 +63,7 @@ func TestGetVideos(t *testing.T) {
 
 func TestGetVideo(t *testing.T) {
 	// Initialize a new Pexels API client
-	client := NewClient(os.Getenv("PEXELS_API_KEY"))
+	client := newLiveClient(t)
 
 	// Set up the parameters for the GetVideo function
 	id := "2499611" // Replace with a valid video ID
@@ -78,3 +79,191 @@ func TestGetVideo(t *testing.T) {
 		t.Errorf("GetVideo failed: response is nil")
 	}
 }
+
+func TestVideoURLs(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(c *Client) error
+		want string
+	}{
+		{"GetVideo", func(c *Client) error {
+			_, err := c.GetVideo(ctx, "2499611")
+			return err
+		}, "https://api.pexels.com/videos/videos/2499611"},
+		{"GetPopularVideos", func(c *Client) error {
+			_, err := c.GetPopularVideos(ctx, &GetPopularVideosParams{MinWidth: 1920})
+			return err
+		}, "https://api.pexels.com/videos/popular?min_width=1920&page=1&per_page=2"},
+		{"GetVideos", func(c *Client) error {
+			_, err := c.GetVideos(ctx, &GetVideosParams{Query: "ocean"})
+			return err
+		}, "https://api.pexels.com/videos/search?page=1&per_page=5&query=ocean"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			client := newStubClient(`{}`, &req)
+			if err := tt.call(client); err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+			if got := req.URL.String(); got != tt.want {
+				t.Errorf("URL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVideoDecodeTagsAndFullRes(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		wantTags    []string
+		wantFullRes *string
+	}{
+		{"absent", `{"id":1}`, nil, nil},
+		{"null", `{"id":1,"tags":null,"full_res":null}`, nil, nil},
+		{"empty", `{"id":1,"tags":[],"full_res":null}`, []string{}, nil},
+		{"populated", `{"id":1,"tags":["sea","beach"],"full_res":"https://example.com/full.mp4"}`, []string{"sea", "beach"}, &[]string{"https://example.com/full.mp4"}[0]},
+		{"non-string values", `{"id":1,"tags":["sea",3,{"a":1}],"full_res":{"w":1}}`, []string{"sea"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Video
+			if err := json.Unmarshal([]byte(tt.json), &v); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if v.ID != 1 {
+				t.Errorf("ID = %d, want 1", v.ID)
+			}
+			if (v.Tags == nil) != (tt.wantTags == nil) || len(v.Tags) != len(tt.wantTags) {
+				t.Fatalf("Tags = %#v, want %#v", v.Tags, tt.wantTags)
+			}
+			for i := range v.Tags {
+				if v.Tags[i] != tt.wantTags[i] {
+					t.Errorf("Tags = %#v, want %#v", v.Tags, tt.wantTags)
+				}
+			}
+			if (v.FullRes == nil) != (tt.wantFullRes == nil) || (v.FullRes != nil && *v.FullRes != *tt.wantFullRes) {
+				t.Errorf("FullRes = %v, want %v", v.FullRes, tt.wantFullRes)
+			}
+
+			// CollectionMedia decodes the same fields identically
+			var m CollectionMedia
+			if err := json.Unmarshal([]byte(tt.json), &m); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if len(m.Tags) != len(tt.wantTags) || (m.FullRes == nil) != (tt.wantFullRes == nil) {
+				t.Errorf("CollectionMedia = %#v, %v", m.Tags, m.FullRes)
+			}
+		})
+	}
+}
+
+func TestFilterVideos(t *testing.T) {
+	resp := &GetVideosResponse{Videos: []Video{
+		{ID: 1, Width: 1920, Height: 1080, Duration: 10},
+		{ID: 2, Width: 1080, Height: 1080, Duration: 30},
+		{ID: 3, Width: 1080, Height: 1920, Duration: 60},
+		{ID: 4, Width: 1080, Height: 0, Duration: 5},
+	}}
+	ids := func(videos []Video) []int {
+		var ids []int
+		for _, v := range videos {
+			ids = append(ids, v.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name string
+		got  []Video
+		want []int
+	}{
+		{"near square", resp.FilterByAspectRatio(0.9, 1.1), []int{2}},
+		{"wide or square", resp.FilterByAspectRatio(1, 0), []int{1, 2}},
+		{"all ratios", resp.FilterByAspectRatio(0, 0), []int{1, 2, 3}},
+		{"short", resp.FilterByDuration(0, 30), []int{1, 2, 4}},
+		{"long", resp.FilterByDuration(30, 0), []int{2, 3}},
+		{"none", resp.FilterByDuration(100, 200), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(tt.got)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestVideoThumbnails(t *testing.T) {
+	v := Video{
+		Image: "https://example.com/image.jpg",
+		VideoPictures: []VideoPicture{
+			{ID: 1, Picture: "https://example.com/0.jpg", Nr: 0},
+			{ID: 2, Picture: "https://example.com/1.jpg", Nr: 1},
+			{ID: 3, Picture: "https://example.com/2.jpg", Nr: 2},
+		},
+	}
+	tests := []struct {
+		nr     int
+		want   string
+		wantOK bool
+	}{
+		{0, "https://example.com/0.jpg", true},
+		{2, "https://example.com/2.jpg", true},
+		{3, "", false},
+		{-1, "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := v.ThumbnailAt(tt.nr); got != tt.want || ok != tt.wantOK {
+			t.Errorf("ThumbnailAt(%d) = %q, %v, want %q, %v", tt.nr, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if got := v.FirstThumbnail(); got != "https://example.com/0.jpg" {
+		t.Errorf("FirstThumbnail() = %q, want the first picture", got)
+	}
+
+	// Without pictures, Image is the fallback
+	v.VideoPictures = nil
+	if _, ok := v.ThumbnailAt(0); ok {
+		t.Errorf("ThumbnailAt(0) found a picture in an empty list")
+	}
+	if got := v.FirstThumbnail(); got != v.Image {
+		t.Errorf("FirstThumbnail() = %q, want %q", got, v.Image)
+	}
+	if got := (Video{}).FirstThumbnail(); got != "" {
+		t.Errorf("FirstThumbnail() = %q, want empty", got)
+	}
+}
+
+func TestDurationString(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{0, "0:00"},
+		{59, "0:59"},
+		{60, "1:00"},
+		{65, "1:05"},
+		{3599, "59:59"},
+		{3600, "1:00:00"},
+		{3661, "1:01:01"},
+		{3723, "1:02:03"},
+		{-5, "0:00"},
+	}
+	for _, tt := range tests {
+		if got := (Video{Duration: tt.seconds}).DurationString(); got != tt.want {
+			t.Errorf("Video.DurationString(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+		if got := (CollectionMedia{Duration: tt.seconds}).DurationString(); got != tt.want {
+			t.Errorf("CollectionMedia.DurationString(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}